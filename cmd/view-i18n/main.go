@@ -0,0 +1,114 @@
+// Command view-i18n extracts translation keys from templates and maintains lang.json files
+//
+// Usage:
+//
+//	view-i18n extract -dir translations -langs en,fr [-prune] src
+//	view-i18n check -dir translations -langs en,fr src
+//
+// extract finds the keys used with the t and translate helpers in the templates
+// at the paths given, and adds any new keys to <lang>.lang.json in dir for each language,
+// keeping existing translations and reporting keys which are no longer used.
+// check exits with status 1 if any key is missing or untranslated for a language.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fragmenta/view/translation"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cmd := os.Args[1]
+	flags := flag.NewFlagSet(cmd, flag.ExitOnError)
+	dir := flags.String("dir", ".", "the directory containing lang.json files")
+	langs := flags.String("langs", translation.DefaultLanguage, "a comma separated list of languages")
+	prune := flags.Bool("prune", false, "remove obsolete keys when extracting")
+	flags.Parse(os.Args[2:])
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"src"}
+	}
+
+	keys, err := translation.Extract(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "view-i18n: error extracting keys %s\n", err)
+		os.Exit(1)
+	}
+
+	switch cmd {
+	case "extract":
+		err = extract(*dir, strings.Split(*langs, ","), keys, *prune)
+	case "check":
+		err = check(*dir, strings.Split(*langs, ","), keys)
+	default:
+		usage()
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "view-i18n: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// extract merges keys into the lang file for each language
+func extract(dir string, langs []string, keys []string, prune bool) error {
+	for _, lang := range langs {
+		p := langPath(dir, lang)
+		result, err := translation.Merge(p, keys, prune)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %d keys, %d added\n", p, len(keys), len(result.Added))
+		for _, k := range result.Obsolete {
+			if prune {
+				fmt.Printf("%s: removed obsolete key %q\n", p, k)
+			} else {
+				fmt.Printf("%s: obsolete key %q\n", p, k)
+			}
+		}
+	}
+	return nil
+}
+
+// check reports missing keys in the lang file for each language
+func check(dir string, langs []string, keys []string) error {
+	count := 0
+	for _, lang := range langs {
+		p := langPath(dir, lang)
+		missing, err := translation.Check(p, keys)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range missing {
+			fmt.Printf("%s: missing key %q\n", p, k)
+		}
+		count += len(missing)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%d missing translations", count)
+	}
+	return nil
+}
+
+// langPath returns the path of the lang file for lang in dir
+func langPath(dir, lang string) string {
+	return filepath.Join(dir, strings.TrimSpace(lang)+".lang.json")
+}
+
+// usage prints usage and exits
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: view-i18n extract|check [-dir dir] [-langs en,fr] [-prune] [paths]\n")
+	os.Exit(2)
+}
@@ -0,0 +1,112 @@
+// Package csrf provides protection against cross site request forgery, with per-session
+// authenticity tokens signed with HMAC, which are masked per request to resist BREACH attacks.
+//
+// Use Middleware to validate tokens on unsafe requests and save the token to the request context,
+// view.NewRenderer copies it to the render context for the csrf and csrfmeta helpers:
+//
+//	<form method="post">{{csrf .}}</form>
+//	<head>{{csrfmeta .}}</head>
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sync"
+)
+
+// Secret is the key used to sign tokens, it should be set on startup to at least 32 random bytes
+// shared by all servers. If it is not set a random secret is generated, so tokens do not survive restarts.
+var Secret []byte
+
+// Mask determines whether tokens are masked with a random pad per request,
+// so that the token sent in responses changes on every request to resist BREACH
+var Mask = true
+
+// tokenLength is the length of an unmasked token in bytes
+const tokenLength = sha256.Size
+
+// ErrInvalidToken is returned when an authenticity token is missing or does not match the session
+var ErrInvalidToken = errors.New("csrf: invalid authenticity token")
+
+var secretOnce sync.Once
+
+// secret returns Secret, generating a random secret if it is not set
+func secret() []byte {
+	secretOnce.Do(func() {
+		if len(Secret) == 0 {
+			Secret = randomBytes(32)
+		}
+	})
+	return Secret
+}
+
+// Token returns the unmasked token for the session id given
+func Token(sessionID string) []byte {
+	mac := hmac.New(sha256.New, secret())
+	mac.Write([]byte(sessionID))
+	return mac.Sum(nil)
+}
+
+// EncodeToken returns the token for the session id given encoded for use in forms,
+// masked with a random pad if Mask is set
+func EncodeToken(sessionID string) string {
+	token := Token(sessionID)
+	if !Mask {
+		return base64.RawURLEncoding.EncodeToString(token)
+	}
+
+	pad := randomBytes(tokenLength)
+	masked := make([]byte, tokenLength*2)
+	copy(masked, pad)
+	xor(masked[tokenLength:], token, pad)
+	return base64.RawURLEncoding.EncodeToString(masked)
+}
+
+// Verify returns nil if the encoded token (masked or unmasked) is valid for the session id given,
+// or ErrInvalidToken if not
+func Verify(sessionID, encoded string) error {
+	if sessionID == "" || encoded == "" {
+		return ErrInvalidToken
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	var token []byte
+	switch len(b) {
+	case tokenLength:
+		token = b
+	case tokenLength * 2:
+		token = make([]byte, tokenLength)
+		xor(token, b[tokenLength:], b[:tokenLength])
+	default:
+		return ErrInvalidToken
+	}
+
+	if !hmac.Equal(token, Token(sessionID)) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// randomBytes returns n bytes from crypto/rand
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic("csrf: error reading random bytes " + err.Error())
+	}
+	return b
+}
+
+// xor sets dst to x XOR y
+func xor(dst, x, y []byte) {
+	for i := range dst {
+		dst[i] = x[i] ^ y[i]
+	}
+}
@@ -0,0 +1,115 @@
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fragmenta/view"
+)
+
+// TestTokens tests tokens are masked and verified for the session
+func TestTokens(t *testing.T) {
+	a, b := EncodeToken("session"), EncodeToken("session")
+	if a == b {
+		t.Errorf("masked tokens should differ per request got:%s", a)
+	}
+	if Verify("session", a) != nil || Verify("session", b) != nil {
+		t.Errorf("masked tokens failed to verify")
+	}
+	if Verify("other", a) != ErrInvalidToken {
+		t.Errorf("token verified for wrong session")
+	}
+
+	// Unmasked tokens are the same for every request
+	Mask = false
+	c, d := EncodeToken("session"), EncodeToken("session")
+	Mask = true
+	if c != d || Verify("session", c) != nil {
+		t.Errorf("unmasked token failed to verify")
+	}
+
+	for _, token := range []string{"", "!!", "abc", a[:len(a)-2]} {
+		if Verify("session", token) != ErrInvalidToken {
+			t.Errorf("invalid token %q verified", token)
+		}
+	}
+	if Verify("", a) != ErrInvalidToken {
+		t.Errorf("token verified without session")
+	}
+}
+
+// TestMiddleware tests the middleware sets tokens and rejects unsafe requests without them
+func TestMiddleware(t *testing.T) {
+	var token string
+	h := Middleware(func(w http.ResponseWriter, r *http.Request) {
+		token, _ = r.Context().Value(view.AuthenticityContext).(string)
+	})
+
+	// A get request sets the session cookie and a token
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/pages/1", nil))
+	cookies := w.Result().Cookies()
+	if w.Code != http.StatusOK || len(cookies) != 1 || cookies[0].Name != CookieName || !cookies[0].HttpOnly {
+		t.Fatalf("middleware failed to set cookie got:%d %v", w.Code, cookies)
+	}
+	if Verify(cookies[0].Value, token) != nil {
+		t.Fatalf("middleware set invalid token:%s", token)
+	}
+	getToken := token
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		header bool
+		cookie bool
+		code   int
+	}{
+		{"form token", "POST", getToken, false, true, http.StatusOK},
+		{"header token", "DELETE", getToken, true, true, http.StatusOK},
+		{"missing token", "POST", "", false, true, http.StatusForbidden},
+		{"wrong token", "PUT", EncodeToken("other"), false, true, http.StatusForbidden},
+		{"missing cookie", "POST", getToken, false, false, http.StatusForbidden},
+		{"safe method", "HEAD", "", false, true, http.StatusOK},
+	}
+	for _, test := range tests {
+		form := url.Values{FieldName: {test.token}}
+		r := httptest.NewRequest(test.method, "/pages/1", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if test.header {
+			r = httptest.NewRequest(test.method, "/pages/1", nil)
+			r.Header.Set(HeaderName, test.token)
+		}
+		if test.cookie {
+			r.AddCookie(cookies[0])
+		}
+
+		w := httptest.NewRecorder()
+		token = ""
+		h(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s failed got:%d want:%d", test.name, w.Code, test.code)
+		}
+		if test.code == http.StatusOK && Verify(cookies[0].Value, token) != nil {
+			t.Errorf("%s failed to set token", test.name)
+		}
+	}
+}
+
+// TestSessionID tests tokens may be bound to app sessions
+func TestSessionID(t *testing.T) {
+	SessionID = func(r *http.Request) string { return r.Header.Get("X-Session") }
+	defer func() { SessionID = nil }()
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("X-Session", "user-session")
+	r.Header.Set(HeaderName, EncodeToken("user-session"))
+	w := httptest.NewRecorder()
+	Middleware(func(w http.ResponseWriter, r *http.Request) {})(w, r)
+	if w.Code != http.StatusOK || len(w.Result().Cookies()) != 0 {
+		t.Errorf("session id failed got:%d", w.Code)
+	}
+}
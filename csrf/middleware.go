@@ -0,0 +1,89 @@
+package csrf
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/fragmenta/view"
+)
+
+// FieldName is the name of the form field containing the authenticity token
+var FieldName = "authenticity_token"
+
+// HeaderName is the name of the request header containing the authenticity token, for scripts
+var HeaderName = "X-CSRF-Token"
+
+// CookieName is the name of the cookie used to store the csrf session id, if SessionID is not set
+var CookieName = "_csrf"
+
+// SecureCookie sets the Secure attribute on the csrf session cookie, it should be true for sites served over https
+var SecureCookie = false
+
+// SessionID optionally returns the session id for a request, so that tokens are bound to the app's sessions,
+// if it is nil a random id is stored in a cookie named CookieName
+var SessionID func(r *http.Request) string
+
+// ErrorHandler is called when a request with an unsafe method has an invalid token
+var ErrorHandler = func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, ErrInvalidToken.Error(), http.StatusForbidden)
+}
+
+// Middleware validates the authenticity token on requests with unsafe methods (not GET, HEAD, OPTIONS or TRACE),
+// read from the form field FieldName or header HeaderName, calling ErrorHandler if it is invalid.
+// It saves the token for the request to the request context for use in views.
+func Middleware(h http.HandlerFunc) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		id := sessionID(w, r)
+
+		if !safeMethod(r.Method) {
+			token := r.Header.Get(HeaderName)
+			if token == "" {
+				token = r.PostFormValue(FieldName)
+			}
+			if Verify(id, token) != nil {
+				ErrorHandler(w, r)
+				return
+			}
+		}
+
+		// Save the token to the request context for use in views
+		ctx := context.WithValue(r.Context(), view.AuthenticityContext, EncodeToken(id))
+		h(w, r.WithContext(ctx))
+	}
+
+}
+
+// safeMethod returns true if the method should not change state
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// sessionID returns the session id for the request, setting a new csrf cookie if required
+func sessionID(w http.ResponseWriter, r *http.Request) string {
+	if SessionID != nil {
+		return SessionID(r)
+	}
+
+	cookie, err := r.Cookie(CookieName)
+	if err == nil && len(cookie.Value) >= 32 {
+		return cookie.Value
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(randomBytes(32))
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    id,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id
+}
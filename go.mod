@@ -0,0 +1,11 @@
+module github.com/fragmenta/view
+
+go 1.25.0
+
+require (
+	github.com/kennygrant/sanitize v1.2.4
+	github.com/pelletier/go-toml v1.9.5
+	github.com/yuin/goldmark v1.8.2
+	golang.org/x/net v0.57.0
+	gopkg.in/yaml.v2 v2.4.0
+)
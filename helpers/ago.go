@@ -0,0 +1,193 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RELATIVE TIMES
+
+// relativeUnits lists the units used in relative times, from smallest to largest
+var relativeUnits = []string{"second", "minute", "hour", "day", "week", "month", "year"}
+
+// RelativeTime formats times relative to the current time, e.g. 5 minutes ago, yesterday or in 3 days
+type RelativeTime struct {
+	// Now returns the current time, it defaults to time.Now and may be replaced in tests
+	Now func() time.Time
+
+	// Locale is used for phrasing, it defaults to DefaultLocale
+	Locale *Locale
+
+	// Location is used to decide calendar days (today, yesterday), it defaults to the location of the time
+	Location *time.Location
+
+	// Granularity is the smallest unit reported (second, minute, hour or day), other values are
+	// treated as the default, minute. Times closer than one unit are reported as just now, or as today for day.
+	Granularity string
+
+	// Short selects the abbreviated style, e.g. 5m or 3h
+	Short bool
+}
+
+// Ago returns a time string reporting distance from the current date
+// of form 5 minutes ago, yesterday or in 3 days.
+// Optional arguments are "short" for the abbreviated style (5m), a granularity
+// (second, minute, hour or day), and a zone or render context as for ZoneDate, e.g. {{ago .t "short"}}
+func Ago(t time.Time, args ...interface{}) string {
+	r := relativeArgs(args)
+	return r.Format(t)
+}
+
+// LangAgo returns a time string reporting distance from the current date
+// in the language given, of form 5 hours ago or il y a 5 heures.
+// It accepts the same optional arguments as Ago.
+func LangAgo(lang string, t time.Time, args ...interface{}) string {
+	r := relativeArgs(args)
+	r.Locale = LocaleFor(lang)
+	return r.Format(t)
+}
+
+// Format returns the time t formatted relative to the current time
+func (r RelativeTime) Format(t time.Time) string {
+	now := time.Now()
+	if r.Now != nil {
+		now = r.Now()
+	}
+	l := r.Locale
+	if l == nil {
+		l = LocaleFor(DefaultLocale)
+	}
+	loc := r.Location
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	duration := now.Sub(t)
+	future := duration < 0
+	if future {
+		duration = -duration
+	}
+	days := calendarDays(now.In(loc), t.In(loc))
+
+	// Choose a unit by magnitude, using calendar days for a day or more
+	unit, n := "", int64(0)
+	switch {
+	case duration < time.Minute:
+		unit, n = "second", int64(duration/time.Second)
+	case duration < time.Hour:
+		unit, n = "minute", int64(duration/time.Minute)
+	case duration < time.Hour*24:
+		unit, n = "hour", int64(duration/time.Hour)
+	case days < 7:
+		unit, n = "day", days
+	default:
+		months := calendarMonths(now.In(loc), t.In(loc))
+		if months < 1 {
+			unit, n = "week", days/7
+		} else if months < 12 {
+			unit, n = "month", months
+		} else {
+			unit, n = "year", months/12
+		}
+	}
+
+	// Report times closer than the granularity as just now or today
+	granularity := granularityIndex(r.Granularity)
+	if unitIndex(unit, 0) < granularity {
+		if granularity < unitIndex("day", 0) {
+			return l.JustNow
+		}
+		unit, n = "day", days
+		if n == 0 {
+			return l.Today
+		}
+	}
+
+	// Use yesterday and tomorrow for a single calendar day
+	if unit == "day" && n == 1 && !r.Short {
+		if future {
+			return l.Tomorrow
+		}
+		return l.Yesterday
+	}
+
+	if r.Short {
+		s := strings.Replace(l.ShortUnits[unit], "%d", formatInt(n, l.Group), 1)
+		if future {
+			return fmt.Sprintf(l.Future, s)
+		}
+		return s
+	}
+
+	if future {
+		return fmt.Sprintf(l.Future, l.unit(unit, n))
+	}
+	return fmt.Sprintf(l.Past, l.unit(unit, n))
+}
+
+// relativeArgs returns a RelativeTime set up from the optional arguments to Ago
+func relativeArgs(args []interface{}) RelativeTime {
+	var r RelativeTime
+	var zones []interface{}
+	for _, a := range args {
+		switch v := a.(type) {
+		case string:
+			switch {
+			case v == "short":
+				r.Short = true
+			case v == "long":
+				r.Short = false
+			case granularityIndex(v) == unitIndex(v, -1):
+				r.Granularity = v
+			}
+		default:
+			zones = append(zones, a)
+		}
+	}
+	_, r.Location = timeArgs(zones, "")
+	return r
+}
+
+// unitIndex returns the index of unit in relativeUnits, or def if not found
+func unitIndex(unit string, def int) int {
+	for i, u := range relativeUnits {
+		if u == unit {
+			return i
+		}
+	}
+	return def
+}
+
+// granularityIndex returns the index of the granularity in relativeUnits, or that of minute
+// if it is not one of the granularities allowed (second, minute, hour or day)
+func granularityIndex(granularity string) int {
+	i := unitIndex(granularity, -1)
+	if i < 0 || i > unitIndex("day", 0) {
+		return unitIndex("minute", 0)
+	}
+	return i
+}
+
+// calendarDays returns the number of calendar days between a and b, ignoring time of day
+func calendarDays(a, b time.Time) int64 {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	days := int64(da.Sub(db).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+// calendarMonths returns the number of whole calendar months between a and b
+func calendarMonths(a, b time.Time) int64 {
+	if a.Before(b) {
+		a, b = b, a
+	}
+	months := int64(a.Year()-b.Year())*12 + int64(a.Month()-b.Month())
+	if a.Day() < b.Day() {
+		months--
+	}
+	return months
+}
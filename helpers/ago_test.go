@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAgo tests relative times in the past and future with a fixed clock
+func TestAgo(t *testing.T) {
+	now := time.Date(2016, time.March, 7, 14, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	r := RelativeTime{Now: clock}
+	short := RelativeTime{Now: clock, Short: true}
+	seconds := RelativeTime{Now: clock, Granularity: "second"}
+	hours := RelativeTime{Now: clock, Granularity: "hour"}
+	days := RelativeTime{Now: clock, Granularity: "day"}
+	weeks := RelativeTime{Now: clock, Granularity: "week"}
+	months := RelativeTime{Now: clock, Granularity: "month"}
+	fr := RelativeTime{Now: clock, Locale: LocaleFor("fr")}
+
+	tests := [][2]string{
+		{r.Format(now), "just now"},
+		{r.Format(now.Add(-30 * time.Second)), "just now"},
+		{seconds.Format(now.Add(-1 * time.Second)), "1 second ago"},
+		{seconds.Format(now.Add(-30 * time.Second)), "30 seconds ago"},
+		{r.Format(now.Add(-1 * time.Minute)), "1 minute ago"},
+		{r.Format(now.Add(-5 * time.Minute)), "5 minutes ago"},
+		{r.Format(now.Add(5 * time.Minute)), "in 5 minutes"},
+		{r.Format(now.Add(-1 * time.Hour)), "1 hour ago"},
+		{r.Format(now.Add(-13 * time.Hour)), "13 hours ago"},
+		{r.Format(now.Add(-25 * time.Hour)), "yesterday"},
+		{r.Format(now.Add(25 * time.Hour)), "tomorrow"},
+		{r.Format(now.Add(-49 * time.Hour)), "2 days ago"},
+		{r.Format(now.Add(72 * time.Hour)), "in 3 days"},
+		{r.Format(now.AddDate(0, 0, -7)), "1 week ago"},
+		{r.Format(now.AddDate(0, 0, -20)), "2 weeks ago"},
+		{r.Format(now.AddDate(0, -1, 0)), "1 month ago"},
+		{r.Format(now.AddDate(0, -11, 0)), "11 months ago"},
+		{r.Format(now.AddDate(-1, 0, 0)), "1 year ago"},
+		{r.Format(now.AddDate(-3, -2, 0)), "3 years ago"},
+		{r.Format(now.AddDate(2, 0, 0)), "in 2 years"},
+		{short.Format(now.Add(-5 * time.Minute)), "5m"},
+		{short.Format(now.Add(-3 * time.Hour)), "3h"},
+		{short.Format(now.Add(-25 * time.Hour)), "1d"},
+		{short.Format(now.Add(3 * time.Hour)), "in 3h"},
+		{hours.Format(now.Add(-30 * time.Minute)), "just now"},
+		{hours.Format(now.Add(-3 * time.Hour)), "3 hours ago"},
+		{days.Format(now.Add(-3 * time.Hour)), "today"},
+		{days.Format(now.AddDate(0, 0, -3)), "3 days ago"},
+		{days.Format(now.AddDate(0, 0, -14)), "2 weeks ago"},
+		{weeks.Format(now.Add(-30 * time.Second)), "just now"},
+		{weeks.Format(now.Add(-5 * time.Minute)), "5 minutes ago"},
+		{months.Format(now.AddDate(0, 0, -10)), "1 week ago"},
+		{days.Format(now.Add(-15 * time.Hour)), "yesterday"},
+		{fr.Format(now.Add(-5 * time.Hour)), "il y a 5 heures"},
+		{fr.Format(now.Add(-25 * time.Hour)), "hier"},
+		{fr.Format(now.AddDate(0, 0, 3)), "dans 3 jours"},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("ago failed got:%s expected:%s", test[0], test[1])
+		}
+	}
+
+	// Check the helpers with the real clock
+	if Ago(time.Now().Add(-5*time.Hour)) != "5 hours ago" || Ago(time.Now().Add(-5*time.Hour), "short") != "5h" {
+		t.Errorf("ago failed got:%s", Ago(time.Now().Add(-5*time.Hour)))
+	}
+
+	// Granularities larger than day are not accepted
+	if Ago(time.Now().Add(-5*time.Minute), "week") != "5 minutes ago" || Ago(time.Now().Add(-5*time.Minute), "hour") != "just now" {
+		t.Errorf("ago granularity failed got:%s", Ago(time.Now().Add(-5*time.Minute), "week"))
+	}
+}
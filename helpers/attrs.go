@@ -0,0 +1,220 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ATTRIBUTES
+
+// Attrs holds html attributes for use with helpers, which escapes names and values when rendered,
+// so unlike attributes given as strings they may contain user input, e.g.
+//
+//	{{field "Title" "title" .title (attrs "class" .cls "data-id" .id "required" true)}}
+//
+// Attributes set to true are rendered as boolean attributes and attributes set to false or nil are omitted.
+// Class attributes are merged rather than replaced.
+type Attrs struct {
+	list []attr
+}
+
+// attr is a single attribute, boolean attributes are rendered without a value
+type attr struct {
+	name    string
+	value   string
+	boolean bool
+}
+
+// attrName matches the attribute names allowed in Attrs
+var attrName = regexp.MustCompile(`^[a-z_:][-a-z0-9_:.]*$`)
+
+// unsafeAttributes are attributes which may run scripts or contain html, and are never allowed in Attrs,
+// as are event handlers such as onclick
+var unsafeAttributes = map[string]bool{"srcdoc": true, "formaction": true, "style": true}
+
+// NewAttrs returns attributes given pairs of names and values, e.g. NewAttrs("class", "button", "disabled", true)
+func NewAttrs(args ...interface{}) (*Attrs, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("helpers: attrs requires pairs of names and values")
+	}
+	a := &Attrs{}
+	for i := 0; i < len(args); i += 2 {
+		name, ok := args[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("helpers: invalid attribute name %v", args[i])
+		}
+		err := a.Set(name, args[i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Set sets the attribute name to the value given, class values are added to the existing classes
+func (a *Attrs) Set(name string, v interface{}) error {
+	name = strings.ToLower(name)
+	if !attrName.MatchString(name) || strings.HasPrefix(name, "on") || unsafeAttributes[name] {
+		return fmt.Errorf("helpers: invalid attribute name %q", name)
+	}
+
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case bool:
+		if !value {
+			a.remove(name)
+			return nil
+		}
+		a.set(attr{name: name, boolean: true})
+	default:
+		a.set(attr{name: name, value: fmt.Sprintf("%v", value)})
+	}
+	return nil
+}
+
+// Get returns the value of the attribute name, or an empty string if it is not set
+func (a *Attrs) Get(name string) string {
+	i := a.index(strings.ToLower(name))
+	if i < 0 {
+		return ""
+	}
+	return a.list[i].value
+}
+
+// Has returns true if the attribute name is set
+func (a *Attrs) Has(name string) bool {
+	return a.index(strings.ToLower(name)) >= 0
+}
+
+// Merge sets the attributes in b on a, merging classes, and returns a
+func (a *Attrs) Merge(b *Attrs) *Attrs {
+	if b != nil {
+		for _, at := range b.list {
+			a.set(at)
+		}
+	}
+	return a
+}
+
+// String returns the attributes escaped for use in an html tag, separated by spaces
+func (a *Attrs) String() string {
+	var attributes []string
+	for _, at := range a.list {
+		if at.boolean {
+			attributes = append(attributes, at.name)
+			continue
+		}
+		value := at.value
+		if urlAttributes[at.name] && value != "" && !DefaultPolicy.allowedURL(value) {
+			value = "#"
+		}
+		attributes = append(attributes, fmt.Sprintf("%s=\"%s\"", at.name, Escape(value)))
+	}
+	return strings.Join(attributes, " ")
+}
+
+// HTMLAttr returns the escaped attributes as go template HTMLAttr
+func (a *Attrs) HTMLAttr() got.HTMLAttr {
+	return got.HTMLAttr(a.String())
+}
+
+// set adds the attribute, replacing an existing attribute of the same name except for class, which is merged
+func (a *Attrs) set(at attr) {
+	i := a.index(at.name)
+	if i < 0 {
+		if at.name == "class" {
+			at.value = mergeClasses("", at.value)
+		}
+		a.list = append(a.list, at)
+		return
+	}
+	if at.name == "class" && !at.boolean {
+		at.value = mergeClasses(a.list[i].value, at.value)
+	}
+	a.list[i] = at
+}
+
+// remove removes the attribute name if present
+func (a *Attrs) remove(name string) {
+	i := a.index(name)
+	if i >= 0 {
+		a.list = append(a.list[:i], a.list[i+1:]...)
+	}
+}
+
+// index returns the index of the attribute name or -1
+func (a *Attrs) index(name string) int {
+	for i, at := range a.list {
+		if at.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeClasses adds the classes in b to those in a, omitting duplicates
+func mergeClasses(a, b string) string {
+	classes := strings.Fields(a)
+	for _, c := range strings.Fields(b) {
+		if !includes(classes, c) {
+			classes = append(classes, c)
+		}
+	}
+	return strings.Join(classes, " ")
+}
+
+// joinAttributes returns the attributes for the args given to helpers, applied in order so that later attributes
+// replace earlier ones (except class, which is merged). The args may be Attrs, or strings and got.HTMLAttr,
+// which are trusted and must not contain user input, but are parsed and escaped again
+func joinAttributes(args []interface{}) (*Attrs, error) {
+	attrs := &Attrs{}
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			err := attrs.parse(a)
+			if err != nil {
+				return nil, err
+			}
+		case got.HTMLAttr:
+			err := attrs.parse(string(a))
+			if err != nil {
+				return nil, err
+			}
+		case *Attrs:
+			attrs.Merge(a)
+		case Attrs:
+			attrs.Merge(&a)
+		default:
+			return nil, fmt.Errorf("helpers: invalid attributes %v", arg)
+		}
+	}
+	return attrs, nil
+}
+
+// parse sets the trusted attributes given as a string, e.g. `class="wide" required`,
+// attributes without a value are set as boolean attributes
+func (a *Attrs) parse(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	z := html.NewTokenizer(strings.NewReader("<p " + s + ">"))
+	if z.Next() != html.StartTagToken {
+		return fmt.Errorf("helpers: invalid attributes %q", s)
+	}
+	token := z.Token()
+	if z.Next() != html.ErrorToken {
+		return fmt.Errorf("helpers: invalid attributes %q", s)
+	}
+	for _, at := range token.Attr {
+		if !attrName.MatchString(at.Key) {
+			return fmt.Errorf("helpers: invalid attribute name %q", at.Key)
+		}
+		a.set(attr{name: at.Key, value: at.Val, boolean: at.Val == ""})
+	}
+	return nil
+}
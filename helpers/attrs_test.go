@@ -0,0 +1,163 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// TestAttrs tests building attributes with escaping, boolean attributes and class merging
+func TestAttrs(t *testing.T) {
+	tests := []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{"class", "button", "data-id", 3}, `class="button" data-id="3"`},
+		{[]interface{}{"title", `a "quoted" <title>`}, `title="a &#34;quoted&#34; &lt;title&gt;"`},
+		{[]interface{}{"required", true, "disabled", false, "placeholder", nil}, `required`},
+		{[]interface{}{"disabled", true, "disabled", false}, ``},
+		{[]interface{}{"class", "a b", "id", "x", "class", " b  c "}, `class="a b c" id="x"`},
+		{[]interface{}{"Data-Name", "x"}, `data-name="x"`},
+		{[]interface{}{"href", "javascript:alert(1)"}, `href="#"`},
+		{[]interface{}{"href", "/pages?a=1&b=2"}, `href="/pages?a=1&amp;b=2"`},
+	}
+	for _, test := range tests {
+		a, err := NewAttrs(test.args...)
+		if err != nil || a.String() != test.expected {
+			t.Errorf("attrs %v failed got:%s %v want:%s", test.args, a, err, test.expected)
+		}
+	}
+
+	invalid := [][]interface{}{
+		{"class"},
+		{1, "x"},
+		{"onclick", "alert(1)"},
+		{"OnLoad", "alert(1)"},
+		{"style", "color:red"},
+		{"srcdoc", "<script></script>"},
+		{`x" onclick="alert(1)`, "y"},
+		{"", "y"},
+	}
+	for _, args := range invalid {
+		if _, err := NewAttrs(args...); err == nil {
+			t.Errorf("attrs %v invalid failed", args)
+		}
+	}
+
+	a, _ := NewAttrs("class", "a", "id", "x")
+	b, _ := NewAttrs("class", "b", "id", "y")
+	if got := a.Merge(b).String(); got != `class="a b" id="y"` || a.Get("id") != "y" || !a.Has("CLASS") {
+		t.Errorf("attrs merge failed got:%s", got)
+	}
+}
+
+// TestHelperAttrs tests helpers accept Attrs alongside trusted attributes
+func TestHelperAttrs(t *testing.T) {
+	a, _ := NewAttrs("class", "wide", "placeholder", `"><script>`)
+	b, _ := NewAttrs("class", "large")
+
+	r, err := Field("Title", "title", "x", `required`, a, b)
+	if err != nil || !strings.Contains(string(r), `required class="wide large" placeholder="&#34;&gt;&lt;script&gt;" type="text"`) {
+		t.Errorf("field attrs failed got:%s %v", r, err)
+	}
+
+	r, err = Link("Home", `/?q="x"`, `rel="nofollow"`, a)
+	if err != nil || r != `<a href="/?q=&#34;x&#34;" rel="nofollow" class="wide" placeholder="&#34;&gt;&lt;script&gt;">Home</a>` {
+		t.Errorf("link attrs failed got:%s %v", r, err)
+	}
+
+	if _, err = Field("Title", "title", "x", 1); err == nil {
+		t.Errorf("field invalid attrs failed")
+	}
+
+	// Strings are parsed and escaped again, and must contain only attributes
+	r, err = Field("Title", "title", "x", `title='a "b"' data-x=a&amp;b`)
+	if err != nil || !strings.Contains(string(r), `title="a &#34;b&#34;" data-x="a&amp;b" type="text"`) {
+		t.Errorf("field string attrs failed got:%s %v", r, err)
+	}
+	for _, s := range []string{`title="a"><script>alert(1)</script>`, `title="a" /><b`, `"a"`} {
+		if r, err = Field("Title", "title", "x", s); err == nil {
+			t.Errorf("field string attrs %q failed got:%s", s, r)
+		}
+		if r, err = Link("Home", "/", s); err == nil {
+			t.Errorf("link string attrs %q failed got:%s", s, r)
+		}
+	}
+}
+
+// FuzzAttrs tests that attribute names and values cannot inject attributes or tags
+func FuzzAttrs(f *testing.F) {
+	f.Add("title", "hello")
+	f.Add("data-x", `"><script>alert(1)</script>`)
+	f.Add("href", "javascript:alert(1)")
+	f.Add("value", "' onmouseover='alert(1)")
+	f.Add(`a" onclick="x`, "y")
+	f.Add("class", "a\x00b\nc")
+	f.Fuzz(func(t *testing.T, name, value string) {
+		a, err := NewAttrs(name, value)
+		if err != nil {
+			return
+		}
+		// Attrs must contain exactly the attribute set when parsed
+		attributes := parseTag(t, "<p "+a.String()+">")
+		if len(attributes) != 1 || attributes[0].Key != strings.ToLower(name) {
+			t.Fatalf("attrs %q %q injected attributes:%v", name, value, attributes)
+		}
+	})
+}
+
+// FuzzField tests that values and attrs given to form helpers cannot inject attributes or tags
+func FuzzField(f *testing.F) {
+	f.Add("hello")
+	f.Add(`"><script>alert(1)</script>`)
+	f.Add("' autofocus onfocus='alert(1)")
+	f.Add("</textarea><script>")
+	f.Fuzz(func(t *testing.T, value string) {
+		a, err := NewAttrs("placeholder", value, "data-value", value)
+		if err != nil {
+			t.Fatalf("attrs failed %s", err)
+		}
+		r, err := Field("", "name", value, a)
+		if err != nil {
+			t.Fatalf("field failed %s", err)
+		}
+		attributes := parseTag(t, string(r))
+		if len(attributes) != 5 {
+			t.Fatalf("field %q injected attributes:%v", value, attributes)
+		}
+		r, err = Link(value, value, a)
+		if err != nil {
+			t.Fatalf("link failed %s", err)
+		}
+		attributes = parseTag(t, string(r))
+		if len(attributes) != 3 {
+			t.Fatalf("link %q injected attributes:%v", value, attributes)
+		}
+	})
+}
+
+// parseTag parses html containing a single start tag, failing if any other tags are found,
+// and returns the attributes of the tag
+func parseTag(t *testing.T, s string) []html.Attribute {
+	var attributes []html.Attribute
+	tags := 0
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if tags != 1 {
+				t.Fatalf("parse %q found %d tags", s, tags)
+			}
+			return attributes
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tags++
+			attributes = z.Token().Attr
+		case html.EndTagToken:
+			if tags != 1 || z.Token().Data != "a" {
+				t.Fatalf("parse %q found unexpected end tag", s)
+			}
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package helpers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ARRAYS
+
+// These helpers accept any slice or array via reflection. Helpers which take an argument
+// as well as a list take the list last, so that they can be used in pipelines e.g.
+// {{range .pages | sortby "Name" | chunk 3}}
+
+// Array takes a set of interface pointers as variadic args, and returns a single array
+func Array(args ...interface{}) []interface{} {
+	return args
+}
+
+// List returns the args given as a list, e.g. {{list 1 2 3}}
+func List(args ...interface{}) []interface{} {
+	return args
+}
+
+// CommaSeparatedArray returns the values as a comma separated string
+func CommaSeparatedArray(args []string) string {
+	return strings.Join(args, ",")
+}
+
+// Append all args to an array, and return that array
+func Append(m []interface{}, args ...interface{}) []interface{} {
+	for _, v := range args {
+		m = append(m, v)
+	}
+	return m
+}
+
+// Contains returns true if the list contains the item, if the map has the item as a key,
+// or if the string contains the item as a substring. Numbers of different types are compared by value.
+func Contains(list interface{}, item interface{}) bool {
+	v := reflect.ValueOf(list)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if equal(v.Index(i).Interface(), item) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if equal(k.Interface(), item) {
+				return true
+			}
+		}
+	case reflect.String:
+		return strings.Contains(v.String(), fmt.Sprintf("%v", item))
+	}
+	return false
+}
+
+// First returns the first item in the list, or nil if it is empty
+func First(list interface{}) interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[0]
+}
+
+// Last returns the last item in the list, or nil if it is empty
+func Last(list interface{}) interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[len(items)-1]
+}
+
+// Slice returns the items in the list from start up to (but not including) the optional end,
+// indexes are clamped to the list bounds, and negative indexes count from the end.
+// Use in templates as sublist, e.g. {{sublist .pages 0 3}}
+func Slice(list interface{}, start int, end ...int) []interface{} {
+	items := toList(list)
+	e := len(items)
+	if len(end) > 0 {
+		e = end[0]
+	}
+	s, e := clampIndex(start, len(items)), clampIndex(e, len(items))
+	if s >= e {
+		return []interface{}{}
+	}
+	return items[s:e]
+}
+
+// Reverse returns the items in the list in reverse order
+func Reverse(list interface{}) []interface{} {
+	items := toList(list)
+	reversed := make([]interface{}, len(items))
+	for i, item := range items {
+		reversed[len(items)-1-i] = item
+	}
+	return reversed
+}
+
+// SortBy returns the items in the list sorted by the field, map key or method given,
+// prefix the field with - to sort in descending order, e.g. {{sortby "-CreatedAt" .pages}}
+func SortBy(field string, list interface{}) ([]interface{}, error) {
+	descending := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	items := toList(list)
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		v, err := fieldValue(item, field)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	// Sort indexes so that items and values stay together
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if descending {
+			return less(values[indexes[j]], values[indexes[i]])
+		}
+		return less(values[indexes[i]], values[indexes[j]])
+	})
+
+	sorted := make([]interface{}, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+	return sorted, nil
+}
+
+// Group is a group of items sharing the same key, as returned by GroupBy
+type Group struct {
+	Key   interface{}
+	Items []interface{}
+}
+
+// GroupBy returns the items in the list grouped by the field, map key or method given,
+// groups are in the order their keys first appear, e.g. {{range groupby "Status" .pages}}{{.Key}}{{end}}
+func GroupBy(field string, list interface{}) ([]*Group, error) {
+	var groups []*Group
+	for _, item := range toList(list) {
+		v, err := fieldValue(item, field)
+		if err != nil {
+			return nil, err
+		}
+
+		var group *Group
+		for _, g := range groups {
+			if equal(g.Key, v) {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &Group{Key: v}
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+	return groups, nil
+}
+
+// Pluck returns the value of the field, map key or method given for each item in the list
+func Pluck(field string, list interface{}) ([]interface{}, error) {
+	items := toList(list)
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		v, err := fieldValue(item, field)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Uniq returns the items in the list with duplicates removed, keeping the first of each
+func Uniq(list interface{}) []interface{} {
+	var unique []interface{}
+	for _, item := range toList(list) {
+		if !Contains(unique, item) {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// Join returns the items in the list joined with the separator given, e.g. {{join ", " .tags}}
+func Join(sep string, list interface{}) string {
+	var s []string
+	for _, item := range toList(list) {
+		s = append(s, fmt.Sprintf("%v", item))
+	}
+	return strings.Join(s, sep)
+}
+
+// Len returns the length of a list, map or string, or 0 for nil and other values.
+// Use in templates as length, e.g. {{length .pages}}
+func Len(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return rv.Len()
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return 0
+		}
+		return Len(rv.Elem().Interface())
+	}
+	return 0
+}
+
+// Chunk splits the list into lists of size items (the last may be shorter),
+// for use in grid layouts e.g. {{range chunk 3 .pages}}<div class="row">...</div>{{end}}
+// The size may be given as a number or string
+func Chunk(size interface{}, list interface{}) ([][]interface{}, error) {
+	n, err := toFloat(size)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("helpers: invalid chunk size %v", size)
+	}
+
+	items := toList(list)
+	var chunks [][]interface{}
+	for i := 0; i < len(items); i += int(n) {
+		end := i + int(n)
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[i:end])
+	}
+	return chunks, nil
+}
+
+// MAPS
+
+// Empty returns an empty map[string]interface{} for use as a context
+func Empty() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+// Map sets a map key and return the map
+func Map(m map[string]interface{}, k string, v interface{}) map[string]interface{} {
+	m[k] = v
+	return m
+}
+
+// Set a map key and return an empty string
+func Set(m map[string]interface{}, k string, v interface{}) string {
+	m[k] = v
+	return "" // Render nothing, we want no side effects
+}
+
+// SetIf sets a map key if the given condition is true
+func SetIf(m map[string]interface{}, k string, v interface{}, t bool) string {
+	if t {
+		m[k] = v
+	} else {
+		m[k] = ""
+	}
+	return "" // Render nothing, we want no side effects
+}
+
+// Dict returns a map given pairs of string keys and values,
+// e.g. {{template "partial.html.got" dict "page" .page "lang" .lang}}
+func Dict(args ...interface{}) (map[string]interface{}, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("helpers: dict requires pairs of keys and values")
+	}
+
+	m := make(map[string]interface{}, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("helpers: dict key %v is not a string", args[i])
+		}
+		m[key] = args[i+1]
+	}
+	return m, nil
+}
+
+// CreateMap - given a set of interface pointers as variadic args, generate and return a map to the values
+// Keys which are not strings are ignored along with their values, see Dict
+func CreateMap(args ...interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, 0)
+	for i := 0; i+1 < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if ok {
+			m[key] = args[i+1]
+		}
+	}
+	return m
+}
+
+// Keys returns the keys of the map given in sorted order
+func Keys(m interface{}) []interface{} {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+
+	var keys []interface{}
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.Interface())
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
+
+// toList returns the items in a slice or array, a nil or other value returns an empty list
+func toList(list interface{}) []interface{} {
+	if items, ok := list.([]interface{}); ok {
+		return items
+	}
+
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items
+}
+
+// fieldValue returns the value of the struct field, map key or method name on item
+func fieldValue(item interface{}, name string) (interface{}, error) {
+	v := reflect.ValueOf(item)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("helpers: no field %s on nil", name)
+	}
+
+	// Methods may be defined on the pointer or value
+	if m := v.MethodByName(name); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() > 0 {
+		return m.Call(nil)[0].Interface(), nil
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, fmt.Errorf("helpers: no field %s on nil", name)
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		f := v.FieldByName(name)
+		if f.IsValid() && f.CanInterface() {
+			return f.Interface(), nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			f := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if f.IsValid() {
+				return f.Interface(), nil
+			}
+			return nil, nil
+		}
+	}
+
+	return nil, fmt.Errorf("helpers: no field %s on %T", name, item)
+}
+
+// equal returns true if a and b are equal, comparing numbers of different types by value
+func equal(a, b interface{}) bool {
+	x, errx := toNumber(a)
+	y, erry := toNumber(b)
+	if errx == nil && erry == nil {
+		return x.float() == y.float()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// less returns true if a sorts before b, comparing numbers, strings, times and bools,
+// other values are compared by their printed representation
+func less(a, b interface{}) bool {
+	x, errx := toNumber(a)
+	y, erry := toNumber(b)
+	if errx == nil && erry == nil {
+		return x.float() < y.float()
+	}
+
+	switch av := a.(type) {
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Before(bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			return !av && bv
+		}
+	}
+
+	return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+}
+
+// clampIndex returns i clamped to the range 0 to n, negative indexes count from the end
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
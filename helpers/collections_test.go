@@ -0,0 +1,250 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testPage struct {
+	ID     int64
+	Name   string
+	Status int
+}
+
+func (p *testPage) Title() string {
+	return "Page " + p.Name
+}
+
+func testPages() []*testPage {
+	return []*testPage{
+		{ID: 1, Name: "b", Status: 100},
+		{ID: 2, Name: "c", Status: 0},
+		{ID: 3, Name: "a", Status: 100},
+	}
+}
+
+// TestList tests list, array and append
+func TestList(t *testing.T) {
+	expected := []interface{}{1, "a"}
+	if r := List(1, "a"); !reflect.DeepEqual(r, expected) {
+		t.Fatalf("list failed got:%v", r)
+	}
+	if r := Array(1, "a"); !reflect.DeepEqual(r, expected) {
+		t.Fatalf("array failed got:%v", r)
+	}
+	if r := Append(List(1), "a"); !reflect.DeepEqual(r, expected) {
+		t.Fatalf("append failed got:%v", r)
+	}
+}
+
+// TestDict tests dict and createmap
+func TestDict(t *testing.T) {
+	m, err := Dict("a", 1, "b", "c")
+	if err != nil || !reflect.DeepEqual(m, map[string]interface{}{"a": 1, "b": "c"}) {
+		t.Fatalf("dict failed got:%v %v", m, err)
+	}
+	if _, err = Dict("a"); err == nil {
+		t.Fatalf("dict odd args failed")
+	}
+	if _, err = Dict(1, 2); err == nil {
+		t.Fatalf("dict non-string key failed")
+	}
+
+	m = CreateMap("a", 1, "b", 2, "c")
+	if !reflect.DeepEqual(m, map[string]interface{}{"a": 1, "b": 2}) {
+		t.Fatalf("createmap failed got:%v", m)
+	}
+}
+
+// TestContains tests contains on slices, maps and strings
+func TestContains(t *testing.T) {
+	tests := []struct {
+		list     interface{}
+		item     interface{}
+		expected bool
+	}{
+		{[]int64{1, 2, 3}, 2, true},
+		{[]int64{1, 2, 3}, int64(4), false},
+		{[]int{1, 2}, 2.0, true},
+		{[]string{"a", "b"}, "b", true},
+		{[]string{"a", "b"}, "c", false},
+		{[2]string{"a", "b"}, "a", true},
+		{map[string]int{"a": 1}, "a", true},
+		{map[string]int{"a": 1}, 1, false},
+		{"hello world", "world", true},
+		{nil, "a", false},
+	}
+	for _, test := range tests {
+		if r := Contains(test.list, test.item); r != test.expected {
+			t.Errorf("contains %v %v failed got:%v want:%v", test.list, test.item, r, test.expected)
+		}
+	}
+}
+
+// TestFirstLast tests first and last
+func TestFirstLast(t *testing.T) {
+	list := []string{"a", "b", "c"}
+	if r := First(list); r != "a" {
+		t.Fatalf("first failed got:%v", r)
+	}
+	if r := Last(list); r != "c" {
+		t.Fatalf("last failed got:%v", r)
+	}
+	if First([]string{}) != nil || Last(nil) != nil {
+		t.Fatalf("first/last empty failed")
+	}
+}
+
+// TestSlice tests slice with clamped and negative indexes
+func TestSlice(t *testing.T) {
+	list := []int{1, 2, 3, 4}
+	tests := []struct {
+		start    int
+		end      []int
+		expected []interface{}
+	}{
+		{1, nil, []interface{}{2, 3, 4}},
+		{1, []int{3}, []interface{}{2, 3}},
+		{0, []int{10}, []interface{}{1, 2, 3, 4}},
+		{-2, nil, []interface{}{3, 4}},
+		{3, []int{1}, []interface{}{}},
+	}
+	for _, test := range tests {
+		if r := Slice(list, test.start, test.end...); !reflect.DeepEqual(r, test.expected) {
+			t.Errorf("slice %d %v failed got:%v want:%v", test.start, test.end, r, test.expected)
+		}
+	}
+}
+
+// TestReverse tests reverse
+func TestReverse(t *testing.T) {
+	if r := Reverse([]int{1, 2, 3}); !reflect.DeepEqual(r, []interface{}{3, 2, 1}) {
+		t.Fatalf("reverse failed got:%v", r)
+	}
+}
+
+// TestSortBy tests sorting by fields, map keys and methods
+func TestSortBy(t *testing.T) {
+	r, err := SortBy("Name", testPages())
+	if err != nil || names(r) != "abc" {
+		t.Fatalf("sortby failed got:%s %v", names(r), err)
+	}
+	r, err = SortBy("-Name", testPages())
+	if err != nil || names(r) != "cba" {
+		t.Fatalf("sortby descending failed got:%s %v", names(r), err)
+	}
+	// Sorting is stable
+	r, err = SortBy("Status", testPages())
+	if err != nil || names(r) != "cba" {
+		t.Fatalf("sortby stable failed got:%s %v", names(r), err)
+	}
+	r, err = SortBy("Title", testPages())
+	if err != nil || names(r) != "abc" {
+		t.Fatalf("sortby method failed got:%s %v", names(r), err)
+	}
+
+	maps := []map[string]interface{}{{"n": 2}, {"n": 1.5}}
+	r, err = SortBy("n", maps)
+	if err != nil || !reflect.DeepEqual(r[0], maps[1]) {
+		t.Fatalf("sortby map failed got:%v %v", r, err)
+	}
+
+	_, err = SortBy("Missing", testPages())
+	if err == nil {
+		t.Fatalf("sortby missing field failed")
+	}
+}
+
+// TestGroupBy tests grouping by a field
+func TestGroupBy(t *testing.T) {
+	groups, err := GroupBy("Status", testPages())
+	if err != nil || len(groups) != 2 {
+		t.Fatalf("groupby failed got:%v %v", groups, err)
+	}
+	if groups[0].Key != 100 || names(groups[0].Items) != "ba" {
+		t.Fatalf("groupby first group failed got:%v %s", groups[0].Key, names(groups[0].Items))
+	}
+	if groups[1].Key != 0 || names(groups[1].Items) != "c" {
+		t.Fatalf("groupby second group failed got:%v %s", groups[1].Key, names(groups[1].Items))
+	}
+}
+
+// TestPluck tests plucking a field
+func TestPluck(t *testing.T) {
+	r, err := Pluck("ID", testPages())
+	if err != nil || !reflect.DeepEqual(r, []interface{}{int64(1), int64(2), int64(3)}) {
+		t.Fatalf("pluck failed got:%v %v", r, err)
+	}
+}
+
+// TestUniq tests uniq
+func TestUniq(t *testing.T) {
+	if r := Uniq([]interface{}{1, "a", int64(1), "a", 2}); !reflect.DeepEqual(r, []interface{}{1, "a", 2}) {
+		t.Fatalf("uniq failed got:%v", r)
+	}
+}
+
+// TestJoin tests join
+func TestJoin(t *testing.T) {
+	if r := Join(", ", []interface{}{1, "a", 2.5}); r != "1, a, 2.5" {
+		t.Fatalf("join failed got:%s", r)
+	}
+}
+
+// TestKeys tests keys are returned sorted
+func TestKeys(t *testing.T) {
+	if r := Keys(map[string]int{"b": 1, "a": 2, "c": 3}); !reflect.DeepEqual(r, []interface{}{"a", "b", "c"}) {
+		t.Fatalf("keys failed got:%v", r)
+	}
+	if r := Keys(map[int]bool{10: true, 9: true}); !reflect.DeepEqual(r, []interface{}{9, 10}) {
+		t.Fatalf("keys int failed got:%v", r)
+	}
+}
+
+// TestLen tests len
+func TestLen(t *testing.T) {
+	var pages []*testPage
+	tests := []struct {
+		v        interface{}
+		expected int
+	}{
+		{[]int{1, 2}, 2},
+		{map[string]int{"a": 1}, 1},
+		{"abc", 3},
+		{nil, 0},
+		{pages, 0},
+		{&[]int{1}, 1},
+		{3, 0},
+	}
+	for _, test := range tests {
+		if r := Len(test.v); r != test.expected {
+			t.Errorf("len %v failed got:%d want:%d", test.v, r, test.expected)
+		}
+	}
+}
+
+// TestChunk tests chunk with numeric and string sizes
+func TestChunk(t *testing.T) {
+	expected := [][]interface{}{{1, 2, 3}, {4, 5}}
+	r, err := Chunk(3, []int{1, 2, 3, 4, 5})
+	if err != nil || !reflect.DeepEqual(r, expected) {
+		t.Fatalf("chunk failed got:%v %v", r, err)
+	}
+	r, err = Chunk("3", []int{1, 2, 3, 4, 5})
+	if err != nil || !reflect.DeepEqual(r, expected) {
+		t.Fatalf("chunk string failed got:%v %v", r, err)
+	}
+	_, err = Chunk(0, []int{1})
+	if err == nil {
+		t.Fatalf("chunk zero failed")
+	}
+}
+
+// names returns the names of test pages joined
+func names(items []interface{}) string {
+	s := ""
+	for _, item := range items {
+		s += item.(*testPage).Name
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CURRENCIES
+
+// Currency holds the data used to format and parse amounts of money in a currency,
+// amounts are stored as an int64 number of minor units (e.g. cents or pence)
+type Currency struct {
+	// Code is the ISO 4217 currency code, e.g. GBP
+	Code string
+
+	// Symbol is the currency symbol, e.g. £
+	Symbol string
+
+	// Digits is the number of minor unit digits, e.g. 2 for GBP or 0 for JPY
+	Digits int
+
+	// SymbolAfter places the symbol after the amount, e.g. 10 kr
+	SymbolAfter bool
+}
+
+// Currencies commonly used, others may be added with RegisterCurrency
+var (
+	GBP = &Currency{Code: "GBP", Symbol: "£", Digits: 2}
+	EUR = &Currency{Code: "EUR", Symbol: "€", Digits: 2}
+	USD = &Currency{Code: "USD", Symbol: "$", Digits: 2}
+	JPY = &Currency{Code: "JPY", Symbol: "¥", Digits: 0}
+)
+
+// currencies holds the currencies available, keyed by code
+var currencies = map[string]*Currency{
+	GBP.Code: GBP,
+	EUR.Code: EUR,
+	USD.Code: USD,
+	JPY.Code: JPY,
+}
+
+// currenciesMu guards currencies
+var currenciesMu sync.RWMutex
+
+// RegisterCurrency adds or replaces the currency for c.Code,
+// it should be called on startup before rendering templates
+func RegisterCurrency(c *Currency) {
+	currenciesMu.Lock()
+	defer currenciesMu.Unlock()
+	currencies[c.Code] = c
+}
+
+// CurrencyFor returns the currency for the ISO 4217 code given, or an error if it is not registered
+func CurrencyFor(code string) (*Currency, error) {
+	currenciesMu.RLock()
+	defer currenciesMu.RUnlock()
+	c := currencies[strings.ToUpper(code)]
+	if c == nil {
+		return nil, fmt.Errorf("helpers: unknown currency %s", code)
+	}
+	return c, nil
+}
+
+// Money formats an amount in minor units (of any integer type, or a whole float) in the currency with the code given,
+// using the separators of the optional lang. Use in templates as {{money .Cents "EUR" .lang}}
+func Money(minor interface{}, code string, lang ...string) (string, error) {
+	n, err := toInteger(minor)
+	if err != nil {
+		return "", err
+	}
+	c, err := CurrencyFor(code)
+	if err != nil {
+		return "", err
+	}
+	return c.Format(n, lang...), nil
+}
+
+// Format returns the amount in minor units formatted with the currency symbol,
+// e.g. £1,234.50 or 1 234,50 € for fr, using the separators of the optional lang
+func (c *Currency) Format(minor int64, lang ...string) string {
+	l := LocaleFor(DefaultLocale)
+	if len(lang) > 0 {
+		l = LocaleFor(lang[0])
+	}
+
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+	}
+
+	// Format using integers, to avoid float rounding
+	s := strconv.FormatUint(absInt64(minor), 10)
+	units, fraction := s, ""
+	if c.Digits > 0 {
+		for len(s) <= c.Digits {
+			s = "0" + s
+		}
+		units, fraction = s[:len(s)-c.Digits], l.Decimal+s[len(s)-c.Digits:]
+	}
+	amount := groupDigits(units, l.Group) + fraction
+
+	if c.SymbolAfter || l.CurrencyAfter {
+		return sign + amount + " " + c.Symbol
+	}
+	return sign + c.Symbol + amount
+}
+
+// Parse returns the amount in minor units given a human friendly price, e.g. £1,234.50 or 1 234,50 €,
+// using the separators of the optional lang. The symbol and code are optional.
+// An error is returned if the price is invalid or has more decimal places than the currency.
+func (c *Currency) Parse(price string, lang ...string) (int64, error) {
+	l := LocaleFor(DefaultLocale)
+	if len(lang) > 0 {
+		l = LocaleFor(lang[0])
+	}
+
+	// Remove the symbol, code, spaces and group separators
+	s := strings.Replace(price, c.Symbol, "", -1)
+	s = strings.Replace(s, c.Code, "", -1)
+	s = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\u202f' {
+			return -1
+		}
+		return r
+	}, s)
+	if l.Group != "" && l.Group != l.Decimal {
+		s = strings.Replace(s, l.Group, "", -1)
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	parts := strings.Split(s, l.Decimal)
+	if len(parts) > 2 || len(parts[0]) == 0 && (len(parts) == 1 || len(parts[1]) == 0) {
+		return 0, fmt.Errorf("helpers: invalid price %q", price)
+	}
+
+	units, fraction := parts[0], ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+
+	// Extra decimal places are only allowed if they are zero
+	if len(fraction) > c.Digits {
+		if strings.Trim(fraction[c.Digits:], "0") != "" {
+			return 0, fmt.Errorf("helpers: too many decimal places in price %q for %s", price, c.Code)
+		}
+		fraction = fraction[:c.Digits]
+	}
+	fraction += strings.Repeat("0", c.Digits-len(fraction))
+
+	digits := units + fraction
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("helpers: invalid price %q", price)
+		}
+	}
+
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("helpers: invalid price %q", price)
+	}
+
+	if negative {
+		minor = -minor
+	}
+	return minor, nil
+}
+
+// absInt64 returns the absolute value of i as a uint64 (which can represent the minimum int64)
+func absInt64(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+	return uint64(i)
+}
@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"testing"
+)
+
+// TestMoney tests formatting amounts in currencies
+func TestMoney(t *testing.T) {
+	tests := [][2]string{
+		{GBP.Format(123456), "£1,234.56"},
+		{GBP.Format(-5), "-£0.05"},
+		{EUR.Format(123456, "fr"), "1\u202f234,56\u00a0€"},
+		{EUR.Format(123456, "nl"), "€1.234,56"},
+		{USD.Format(100), "$1.00"},
+		{JPY.Format(1000), "¥1,000"},
+		{CentsToPrice(1000), "£10"},
+		{CentsToPrice(4530), "£45.30"},
+		{CentsToPrice(123450), "£1234.50"},
+		{CentsToPrice(-5), "£-0.05"},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("money failed got:%q expected:%q", test[0], test[1])
+		}
+	}
+
+	m, err := Money(999, "eur", "de")
+	if err != nil || m != "9,99\u00a0€" {
+		t.Errorf("money failed got:%q %v", m, err)
+	}
+
+	_, err = Money(999, "XXX")
+	if err == nil {
+		t.Errorf("money failed to report unknown currency")
+	}
+
+	for _, v := range []interface{}{int(999), int32(999), uint(999), float64(999)} {
+		m, err = Money(v, "EUR")
+		if err != nil || m != "€9.99" {
+			t.Errorf("money %T failed got:%q %v", v, m, err)
+		}
+	}
+	_, err = Money("999", "EUR")
+	if err == nil {
+		t.Errorf("money failed to report invalid minor units")
+	}
+}
+
+// TestParseMoney tests parsing amounts in currencies
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		currency *Currency
+		lang     string
+		price    string
+		minor    int64
+	}{
+		{GBP, "en", "£1,234.56", 123456},
+		{GBP, "en", "-£3", -300},
+		{GBP, "en", "45.3", 4530},
+		{GBP, "en", ".50", 50},
+		{GBP, "en", "12.340", 1234},
+		{EUR, "fr", "1\u202f234,56\u00a0€", 123456},
+		{EUR, "fr", "1 234,56 EUR", 123456},
+		{EUR, "de", "1.234,5", 123450},
+		{USD, "en", "USD 20", 2000},
+		{JPY, "en", "¥1,000", 1000},
+	}
+
+	for _, test := range tests {
+		minor, err := test.currency.Parse(test.price, test.lang)
+		if err != nil || minor != test.minor {
+			t.Errorf("parse money failed for %q got:%d %v expected:%d", test.price, minor, err, test.minor)
+		}
+	}
+
+	invalid := []string{"", "£", "12.345", "1.2.3", "12a", "£99999999999999999999"}
+	for _, price := range invalid {
+		_, err := GBP.Parse(price)
+		if err == nil {
+			t.Errorf("parse money failed to report error for %q", price)
+		}
+	}
+
+	_, err := JPY.Parse("¥10.5")
+	if err == nil {
+		t.Errorf("parse money failed to report decimal places for JPY")
+	}
+}
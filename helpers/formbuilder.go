@@ -0,0 +1,357 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MethodOverrideField is the name of the hidden field used to send methods other than GET and POST from forms
+var MethodOverrideField = "_method"
+
+// FormBuilder renders form fields bound to the fields of a model, reading values from
+// struct fields, map keys or getter methods. Field names and labels are read from the struct tags
+// form and label if present, e.g. `form:"title" label:"Page title"`, or derived from the field name.
+//
+//	{{$f := form .page "/pages/1/update" "patch" .}}
+//	{{$f.Open}}
+//	{{$f.Text "Title"}}
+//	{{$f.Select "Status" .statusOptions}}
+//	{{$f.Submit "Save"}}
+//	{{$f.Close}}
+type FormBuilder struct {
+	// Model is the struct, pointer to struct or map the form edits
+	Model interface{}
+
+	// Action is the url the form is submitted to
+	Action string
+
+	// Method is the http method, methods other than GET and POST are sent with a method override field
+	Method string
+
+	// Token is the authenticity token sent with the form, if any
+	Token string
+
+	// Errors holds validation errors for fields, which are shown with the fields
+	Errors FormErrors
+
+	// Location is the time zone date and time fields are shown in, if any
+	Location *time.Location
+}
+
+// Form returns a FormBuilder for the model and action given, the remaining args may be
+// a method (default post), FormErrors, a *time.Location, and the render context, from which the authenticity token,
+// form errors (see view.Renderer.Errors) and time zone are read
+func Form(model interface{}, action string, args ...interface{}) (*FormBuilder, error) {
+	f := &FormBuilder{Model: model, Action: action, Method: "post"}
+	for _, a := range args {
+		switch v := a.(type) {
+		case string:
+			f.Method = strings.ToLower(v)
+		case map[string]interface{}:
+			if token, ok := v[authenticityKey].(string); ok {
+				f.Token = token
+			}
+			if errors, ok := v[formErrorsKey].(FormErrors); ok && f.Errors == nil {
+				f.Errors = errors
+			}
+			if loc := zoneLocation(v); loc != nil && f.Location == nil {
+				f.Location = loc
+			}
+		case FormErrors:
+			f.Errors = v
+		case *time.Location:
+			f.Location = v
+		default:
+			return nil, fmt.Errorf("helpers: invalid form argument %v", a)
+		}
+	}
+	return f, nil
+}
+
+// Open renders the forms/form.html.got partial, which opens the form
+// and includes method override and authenticity token fields if required
+func (f *FormBuilder) Open(args ...interface{}) (got.HTML, error) {
+	method, override := f.Method, ""
+	if method != "get" && method != "post" {
+		method, override = "post", f.Method
+	}
+	token := ""
+	if method != "get" {
+		token = f.Token
+	}
+
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	return Partial("forms/form.html.got", map[string]interface{}{
+		"action":        f.Action,
+		"method":        method,
+		"override":      override,
+		"overrideField": MethodOverrideField,
+		"token":         token,
+		"tokenField":    authenticityKey,
+		"attributes":    attributes.HTMLAttr(),
+	})
+}
+
+// Close closes the form
+func (f *FormBuilder) Close() got.HTML {
+	return got.HTML("</form>")
+}
+
+// Text renders a text field for the model field given
+func (f *FormBuilder) Text(field string, args ...interface{}) (got.HTML, error) {
+	return f.input(field, "text", args)
+}
+
+// Password renders a password field for the model field given, the value is never rendered
+func (f *FormBuilder) Password(field string, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	return renderField(label, name, "", f.Errors[name], append(args, `type="password"`))
+}
+
+// Hidden renders a hidden field for the model field given
+func (f *FormBuilder) Hidden(field string, args ...interface{}) (got.HTML, error) {
+	name, _, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return Field("", name, v, append(args, `type="hidden"`)...)
+}
+
+// TextArea renders a textarea for the model field given
+func (f *FormBuilder) TextArea(field string, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return renderTextArea(label, name, v, f.Errors[name], args)
+}
+
+// Date renders a date field for the model field given, which must be a time.Time
+func (f *FormBuilder) Date(field string, args ...interface{}) (got.HTML, error) {
+	name, label, t, err := f.time(field)
+	if err != nil {
+		return "", err
+	}
+	return renderDateField(label, name, t, f.Errors[name], append(args, f.Location))
+}
+
+// DateTime renders a datetime field for the model field given, which must be a time.Time
+func (f *FormBuilder) DateTime(field string, args ...interface{}) (got.HTML, error) {
+	name, label, t, err := f.time(field)
+	if err != nil {
+		return "", err
+	}
+	return renderTimeField(label, name, t, DateTimeFieldFormat, f.Errors[name], append(args, f.Location))
+}
+
+// Time renders a time field for the model field given, which must be a time.Time
+func (f *FormBuilder) Time(field string, args ...interface{}) (got.HTML, error) {
+	name, label, t, err := f.time(field)
+	if err != nil {
+		return "", err
+	}
+	return renderTimeField(label, name, t, TimeFieldFormat, f.Errors[name], append(args, f.Location))
+}
+
+// Select renders a select for the model field given, with options as for the Select helper
+func (f *FormBuilder) Select(field string, options interface{}, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return renderSelect(label, name, v, options, f.Errors[name], args)
+}
+
+// Checkbox renders a checkbox for the model field given, which must be a bool
+func (f *FormBuilder) Checkbox(field string, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	checked, ok := v.(bool)
+	if !ok {
+		return "", fmt.Errorf("helpers: form field %s is not a bool", field)
+	}
+	return renderCheckbox(label, name, checked, f.Errors[name], args)
+}
+
+// RadioGroup renders a group of radio buttons for the model field given, with a slice of Selectable options
+func (f *FormBuilder) RadioGroup(field string, options interface{}, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return renderRadioGroup(label, name, v, options, f.Errors[name], args)
+}
+
+// SelectMultiple renders a multiple select for the model field given, which must be a slice of values
+func (f *FormBuilder) SelectMultiple(field string, options interface{}, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return renderSelect(label, name, multipleValues(v), options, f.Errors[name], args)
+}
+
+// Number renders a number input for the model field given, numeric args are the min, max and step
+func (f *FormBuilder) Number(field string, args ...interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return numberInput("number", label, name, v, f.Errors[name], args)
+}
+
+// Email renders an email input for the model field given
+func (f *FormBuilder) Email(field string, args ...interface{}) (got.HTML, error) {
+	return f.input(field, "email", args)
+}
+
+// Tel renders a telephone number input for the model field given
+func (f *FormBuilder) Tel(field string, args ...interface{}) (got.HTML, error) {
+	return f.input(field, "tel", args)
+}
+
+// URL renders a url input for the model field given
+func (f *FormBuilder) URL(field string, args ...interface{}) (got.HTML, error) {
+	return f.input(field, "url", args)
+}
+
+// Submit renders a submit button with the text given
+func (f *FormBuilder) Submit(text string, args ...interface{}) (got.HTML, error) {
+	return Field("", "", text, append(args, `type="submit"`)...)
+}
+
+// input renders an input of type t for the model field given
+func (f *FormBuilder) input(field, t string, args []interface{}) (got.HTML, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", err
+	}
+	return renderField(label, name, v, f.Errors[name], append(args, fmt.Sprintf("type=%q", t)))
+}
+
+// time returns the name, label and value of the model field given, which must be a time.Time
+func (f *FormBuilder) time(field string) (string, string, time.Time, error) {
+	name, label, err := f.names(field)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	v, err := f.value(field)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	t, ok := v.(time.Time)
+	if !ok {
+		return "", "", time.Time{}, fmt.Errorf("helpers: form field %s is not a time", field)
+	}
+	return name, label, t, nil
+}
+
+// value returns the value of the model field given, nil values are returned as an empty string
+func (f *FormBuilder) value(field string) (interface{}, error) {
+	v, err := fieldValue(f.Model, field)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return "", nil
+	}
+	return v, nil
+}
+
+// names returns the name and label for the model field given, read from struct tags if present
+func (f *FormBuilder) names(field string) (string, string, error) {
+	name, label := fieldName(field), fieldLabel(field)
+
+	t := reflect.TypeOf(f.Model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return name, label, nil
+	}
+
+	sf, ok := t.FieldByName(field)
+	if !ok {
+		return name, label, nil
+	}
+	if tag := strings.Split(sf.Tag.Get("form"), ",")[0]; tag == "-" {
+		return "", "", fmt.Errorf("helpers: form field %s is not editable", field)
+	} else if tag != "" {
+		name = tag
+	}
+	if tag := sf.Tag.Get("label"); tag != "" {
+		label = tag
+	}
+	return name, label, nil
+}
+
+// fieldName returns a form field name for a go field name, e.g. AuthorID => author_id
+func fieldName(field string) string {
+	return strings.ToLower(strings.Join(splitWords(field), "_"))
+}
+
+// fieldLabel returns a form label for a go field name, e.g. CreatedAt => Created At
+func fieldLabel(field string) string {
+	return strings.Join(splitWords(field), " ")
+}
+
+// splitWords splits a camel case go name into words, keeping initialisms together e.g. AuthorID => Author ID
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		upper := unicode.IsUpper(runes[i])
+		// Start a new word at an upper case letter following a lower case letter,
+		// or at the last upper case letter of an initialism followed by a lower case letter
+		if upper && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
@@ -0,0 +1,149 @@
+package helpers
+
+import (
+	"bytes"
+	got "html/template"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testFormPage struct {
+	Title     string
+	Summary   string `form:"page_summary" label:"Short summary"`
+	AuthorID  int64
+	Status    int
+	Secret    string `form:"-"`
+	CreatedAt time.Time
+}
+
+func (p *testFormPage) Slug() string {
+	return "my-page"
+}
+
+// TestFormBuilder tests fields are bound to model values, names and labels
+func TestFormBuilder(t *testing.T) {
+	page := &testFormPage{Title: "My <page>", Summary: "sum", AuthorID: 3, Status: 1, CreatedAt: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)}
+	f, err := Form(page, "/pages/1/update", "PATCH", map[string]interface{}{"authenticity_token": "tok"})
+	if err != nil {
+		t.Fatalf("form failed err:%s", err)
+	}
+
+	r, err := f.Open(`class="edit"`)
+	assertHTML(t, "open", r, err, `<form action="/pages/1/update" method="post" accept-charset="UTF-8" class="edit">`, `<input type="hidden" name="_method" value="patch">`, `<input type="hidden" name="authenticity_token" value="tok">`)
+	r, err = f.Text("Title")
+	assertHTML(t, "text", r, err, `<label>Title</label>`, `<input name="title" value="My &lt;page&gt;" type="text">`)
+	r, err = f.TextArea("Summary")
+	assertHTML(t, "tags", r, err, `<label>Short summary</label>`, `<textarea name="page_summary" >sum</textarea>`)
+	r, err = f.Hidden("AuthorID")
+	assertHTML(t, "derived names", r, err, `<input name="author_id" value="3" type="hidden">`)
+	r, err = f.Text("Slug")
+	assertHTML(t, "method", r, err, `<label>Slug</label>`, `name="slug" value="my-page"`)
+	r, err = f.Date("CreatedAt")
+	assertHTML(t, "date", r, err, `<label>Created At</label>`, `name="created_at"`, `data-date="2020-03-04"`)
+	r, err = f.Select("Status", NumberOptions(0, 2))
+	assertHTML(t, "select", r, err, `<select type="select" name="status" id="status">`, `<option value="1" selected>1</option>`)
+	r, err = f.Password("Title")
+	assertHTML(t, "password", r, err, `value="" type="password"`)
+	r, err = f.Submit("Save")
+	assertHTML(t, "submit", r, err, `value="Save" type="submit"`)
+
+	if _, err = f.Text("Secret"); err == nil {
+		t.Errorf("form excluded field failed")
+	}
+	if _, err = f.Text("Missing"); err == nil {
+		t.Errorf("form missing field failed")
+	}
+	if _, err = f.Date("Title"); err == nil {
+		t.Errorf("form date field type failed")
+	}
+}
+
+// TestFormBuilderTemplate tests the form builder used within a template
+func TestFormBuilderTemplate(t *testing.T) {
+	tmpl := got.Must(got.New("").Funcs(got.FuncMap{"form": Form}).Parse(
+		`{{$f := form .page "/pages" .}}{{$f.Open}}{{$f.Text "Title"}}{{$f.Close}}`))
+
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, map[string]interface{}{
+		"page":               map[string]interface{}{"Title": "t"},
+		"authenticity_token": "tok",
+	})
+	if err != nil {
+		t.Fatalf("form template failed err:%s", err)
+	}
+	s := b.String()
+	if !strings.HasPrefix(s, `<form action="/pages" method="post"`) || !strings.Contains(s, `value="tok"`) ||
+		!strings.Contains(s, `name="title" value="t"`) || !strings.HasSuffix(s, "</form>") {
+		t.Errorf("form template failed got:%s", s)
+	}
+	if strings.Contains(s, "_method") {
+		t.Errorf("form template post override failed got:%s", s)
+	}
+}
+
+// TestSplitWords tests derived names and labels
+func TestSplitWords(t *testing.T) {
+	tests := [][3]string{
+		{"Title", "title", "Title"},
+		{"AuthorID", "author_id", "Author ID"},
+		{"CreatedAt", "created_at", "Created At"},
+		{"HTMLBody", "html_body", "HTML Body"},
+	}
+	for _, test := range tests {
+		if r := fieldName(test[0]); r != test[1] {
+			t.Errorf("field name %s failed got:%s want:%s", test[0], r, test[1])
+		}
+		if r := fieldLabel(test[0]); r != test[2] {
+			t.Errorf("field label %s failed got:%s want:%s", test[0], r, test[2])
+		}
+	}
+}
+
+// TestFormErrors tests errors are shown with fields and in the summary
+func TestFormErrors(t *testing.T) {
+	errors := FormErrors{}
+	errors.Add("title", "Title is required")
+	errors.Add("title", "Title is too short")
+	errors.Add("status", "Status is invalid")
+
+	context := map[string]interface{}{"form_errors": errors}
+	page := &testFormPage{CreatedAt: time.Now()}
+	f, err := Form(page, "/pages", context)
+	if err != nil || errors.Empty() || !errors.Has("title") || errors.Has("summary") {
+		t.Fatalf("form errors failed err:%v", err)
+	}
+
+	r, err := f.Text("Title")
+	assertHTML(t, "text", r, err, `<div class="field field-error">`, `type="text" aria-invalid="true" aria-describedby="title_error">`, `<span class="field-error-message" id="title_error">Title is required Title is too short</span>`)
+	r, err = f.Select("Status", NumberOptions(0, 1))
+	assertHTML(t, "select", r, err, `id="status" aria-invalid="true" aria-describedby="status_error">`, `id="status_error">Status is invalid</span>`)
+	r, err = f.TextArea("Summary")
+	assertHTML(t, "valid", r, err, `<div class="field">`, `<textarea name="page_summary" ></textarea>`)
+	if strings.Contains(string(r), "aria-invalid") {
+		t.Errorf("valid failed got:%s", r)
+	}
+	r, err = Field("Title", "title", "", context)
+	assertHTML(t, "field", r, err, `aria-invalid="true" aria-describedby="title_error">`, `id="title_error">Title is required Title is too short</span>`)
+	r, err = DateField("Title", "title", time.Time{}, errors)
+	assertHTML(t, "datefield", r, err, `aria-invalid="true"`, `id="title_error">`)
+	r, err = Checkbox("Status", "status", false, context)
+	assertHTML(t, "checkbox", r, err, `aria-invalid="true"`, `id="status_error">Status is invalid</span>`)
+	r, err = Select("Status", "status", 0, NumberOptions(0, 1), context)
+	assertHTML(t, "standalone select", r, err, `aria-invalid="true"`, `id="status_error">Status is invalid</span>`)
+	r, err = Field("", "title", "", context)
+	assertHTML(t, "unlabelled field", r, err, `aria-invalid="true" aria-describedby="title_error">`, `id="title_error">Title is required Title is too short</span>`)
+	r, err = Select("", "status", 0, NumberOptions(0, 1), context)
+	assertHTML(t, "unlabelled select", r, err, `aria-invalid="true" aria-describedby="status_error">`, `</select><span class="field-error-message" id="status_error">Status is invalid</span>`)
+	r, err = ErrorSummary(context)
+	assertHTML(t, "summary", r, err, `<div class="error-summary" role="alert">`, `<li><a href="#status_error">Status is invalid</a></li>`, `<li><a href="#title_error">Title is too short</a></li>`)
+
+	r, err = ErrorSummary(FormErrors{})
+	if err != nil || r != "" {
+		t.Errorf("empty summary failed got:%s %v", r, err)
+	}
+
+	if _, err = Select("Status", "status", 0, NumberOptions(0, 1), 1); err == nil {
+		t.Errorf("select invalid args failed")
+	}
+}
@@ -0,0 +1,117 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"sort"
+	"time"
+)
+
+// formErrorsKey is the render context key for form errors, as set by view
+const formErrorsKey = "form_errors"
+
+// FormErrors holds validation error messages for a form, keyed by field name
+type FormErrors map[string][]string
+
+// Add adds a message for the field name given
+func (e FormErrors) Add(name, message string) {
+	e[name] = append(e[name], message)
+}
+
+// Get returns the messages for the field name given
+func (e FormErrors) Get(name string) []string {
+	return e[name]
+}
+
+// Has returns true if there are messages for the field name given
+func (e FormErrors) Has(name string) bool {
+	return len(e[name]) > 0
+}
+
+// Empty returns true if there are no messages
+func (e FormErrors) Empty() bool {
+	for _, m := range e {
+		if len(m) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FormError is a single error message, as listed in the error summary
+type FormError struct {
+	// Field is the field name
+	Field string
+
+	// ID is the id of the element containing the field messages
+	ID string
+
+	// Message is the error message
+	Message string
+}
+
+// ErrorSummary renders the forms/errorsummary.html.got partial listing all errors, sorted by field name,
+// given FormErrors or the render context, e.g. {{errorsummary .}}
+func ErrorSummary(v interface{}) (got.HTML, error) {
+	errors, err := formErrors(v)
+	if err != nil {
+		return "", err
+	}
+
+	var names []string
+	for name := range errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list []FormError
+	for _, name := range names {
+		for _, m := range errors[name] {
+			list = append(list, FormError{Field: name, ID: name + "_error", Message: m})
+		}
+	}
+
+	return Partial("forms/errorsummary.html.got", map[string]interface{}{
+		"errors": list,
+	})
+}
+
+// formErrors returns the form errors given FormErrors or a render context
+func formErrors(v interface{}) (FormErrors, error) {
+	switch e := v.(type) {
+	case FormErrors:
+		return e, nil
+	case map[string][]string:
+		return FormErrors(e), nil
+	case map[string]interface{}:
+		if errors, ok := e[formErrorsKey].(FormErrors); ok {
+			return errors, nil
+		}
+		return nil, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("helpers: invalid form errors %v", v)
+}
+
+// fieldArgs returns the args given to field helpers without any render context, FormErrors or *time.Location,
+// the validation errors for the field name and the time zone they contain, if any
+func fieldArgs(name string, args []interface{}) ([]interface{}, []string, *time.Location) {
+	var errors FormErrors
+	var rest []interface{}
+	for _, a := range args {
+		switch v := a.(type) {
+		case FormErrors:
+			errors = v
+		case map[string]interface{}:
+			if e, ok := v[formErrorsKey].(FormErrors); ok && errors == nil {
+				errors = e
+			}
+			rest = append(rest, a)
+		default:
+			rest = append(rest, a)
+		}
+	}
+	rest, loc := zoneArgs(rest)
+	return rest, errors.Get(name), loc
+}
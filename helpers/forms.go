@@ -4,6 +4,7 @@ import (
 	"fmt"
 	got "html/template"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -11,98 +12,218 @@ import (
 
 // FORMS
 
-// These should probably use templates from or from lib, so that users can change what form fields get generated
-// and use templ rather than fmt.Sprintf
+// Form fields are rendered with partials, which apps may override to change the markup generated,
+// for example by adding forms/field.html.got to their templates (see defaultPartials for the default theme)
 
-// We need to set this token in the session on the get request for the form
+// Values, labels and options are always escaped by the partials, so they may contain user input.
+// Labels of type got.HTML (see the html helper) are trusted and inserted without escaping,
+// so they must not contain user input, e.g. {{checkbox (html "I agree to the <a href=\"/terms\">terms</a>") "terms" false}}
 
-// CSRF generates an input field tag containing a CSRF token
-func CSRF() got.HTML {
-	token := "my_csrf_token" // instead of generating this here, should we instead get router or app to generate and put into the context?
-	output := fmt.Sprintf("<input type='hidden' name='csrf' value='%s'>", token)
-	return got.HTML(output)
+// Field helpers accept the render context or FormErrors in their args, and show the validation errors
+// for the field (see view.Renderer.Errors), e.g. {{field "Name" "name" .name .}}
+
+// authenticityKey is the render context key and form field name for authenticity tokens, as set by view
+const authenticityKey = "authenticity_token"
+
+// CSRF renders a hidden field containing the authenticity token, given the render context or a token,
+// e.g. {{csrf}} or {{csrf .}} (see the csrf package for middleware which sets the token).
+// The render context is passed to csrf automatically in templates (see view.ContextHelpers).
+func CSRF(args ...interface{}) (got.HTML, error) {
+	token, err := authenticityToken(args)
+	if err != nil {
+		return "", err
+	}
+	return got.HTML(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`, authenticityKey, Escape(token))), nil
 }
 
-// Field accepts name string, value interface{}, fieldType string, args ...string
-func Field(label string, name string, v interface{}, args ...string) got.HTML {
-	attributes := ""
-	if len(args) > 0 {
-		attributes = strings.Join(args, " ")
+// CSRFMeta renders a meta tag containing the authenticity token for use by scripts,
+// given the render context or a token, e.g. {{csrfmeta}} or {{csrfmeta .}}
+func CSRFMeta(args ...interface{}) (got.HTML, error) {
+	token, err := authenticityToken(args)
+	if err != nil {
+		return "", err
 	}
-	// If no type, add it to attributes
-	if !strings.Contains(attributes, "type=") {
-		attributes = attributes + " type=\"text\""
+	return got.HTML(fmt.Sprintf(`<meta name="csrf-token" content="%s">`, Escape(token))), nil
+}
+
+// authenticityToken returns the first token found in the render contexts or tokens given,
+// or an error if there is none
+func authenticityToken(args []interface{}) (string, error) {
+	for _, a := range args {
+		token := ""
+		switch t := a.(type) {
+		case string:
+			token = t
+		case map[string]interface{}:
+			token, _ = t[authenticityKey].(string)
+		}
+		if token != "" {
+			return token, nil
+		}
 	}
+	return "", fmt.Errorf("helpers: no authenticity token found")
+}
 
-	tmpl :=
-		`<div class="field">
-         <label>%s</label>
-         <input name="%s" value="%s" %s>
-         </div>`
+// Field renders the forms/field.html.got partial given a label, name, value and attributes,
+// the field type is text unless a type attribute is given
+func Field(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField(label, name, v, errors, args)
+}
 
-	if label == "" {
-		tmpl = `%s<input name="%s" value="%s" %s>`
+// renderField renders the forms/field.html.got partial with validation errors for the field
+func renderField(label interface{}, name string, v interface{}, errors []string, args []interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
 	}
+	// If no type, add it to attributes
+	if !attributes.Has("type") {
+		attributes.set(attr{name: "type", value: "text"})
+	}
+
+	return Partial("forms/field.html.got", fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"value":      fmt.Sprintf("%v", v),
+		"attributes": attributes.HTMLAttr(),
+	}))
+}
 
-	output := fmt.Sprintf(tmpl, Escape(label), Escape(name), Escape(fmt.Sprintf("%v", v)), attributes)
+// FieldFormat sets the input type and layout used to render and parse date and time fields
+type FieldFormat struct {
+	// Type is the input type, inputs such as date send values in a fixed layout which must be used
+	Type string
 
-	return got.HTML(output)
+	// Layout is the layout of values, as for time.Format
+	Layout string
 }
 
-// DateField sets up a date field with a data-date attribute storing the real date
-func DateField(label string, name string, t time.Time, args ...string) got.HTML {
+// DateFieldFormat is the format of date fields, which use text inputs by default
+// because of inconsistent browser behaviour and to support our own date picker popups
+var DateFieldFormat = FieldFormat{Type: "text", Layout: "Jan 2, 2006"}
 
-	// NB we use text type for date fields because of inconsistent browser behaviour
-	// and to support our own date picker popups
-	tmpl :=
-		`<div class="field">
-         <label>%s</label>
-         <input name="%s" id="%s" class="date_field" type="text" value="%s" data-date="%s" %s autocomplete="off">
-         </div>`
+// dateLayout is the layout of date input values, also used for the data-date attribute of date fields
+const dateLayout = "2006-01-02"
 
-	attributes := ""
-	if len(args) > 0 {
-		attributes = strings.Join(args, " ")
+// DateField renders the forms/datefield.html.got partial, with a data-date attribute storing the real date,
+// zero times are rendered as blank. The args may include a *time.Location or render context,
+// to show the date in that time zone, e.g. {{datefield "Published" "published_at" .page.PublishedAt .}}
+func DateField(label interface{}, name string, t time.Time, args ...interface{}) (got.HTML, error) {
+	args, errors, loc := fieldArgs(name, args)
+	return renderDateField(label, name, t, errors, append(args, loc))
+}
+
+// renderDateField renders the forms/datefield.html.got partial with validation errors for the field
+func renderDateField(label interface{}, name string, t time.Time, errors []string, args []interface{}) (got.HTML, error) {
+	args, loc := zoneArgs(args)
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
 	}
-	output := fmt.Sprintf(tmpl, Escape(label), Escape(name), Escape(name), Date(t), Date(t, "2006-01-02"), attributes)
+	// The partial sets the type from DateFieldFormat
+	attributes.remove("type")
+	return Partial("forms/datefield.html.got", fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"id":         name,
+		"type":       DateFieldFormat.Type,
+		"value":      formatTime(t, DateFieldFormat.Layout, loc),
+		"date":       formatTime(t, dateLayout, loc),
+		"attributes": attributes.HTMLAttr(),
+	}))
+}
 
-	return got.HTML(output)
+// ParseDateField parses a value sent by a date field, in the layout of DateFieldFormat or 2006-01-02,
+// in the time zone given as a *time.Location, zone name or render context, or UTC if none.
+// Blank values return a zero time.
+func ParseDateField(s string, zone ...interface{}) (time.Time, error) {
+	return parseTime(s, zone, DateFieldFormat.Layout, dateLayout)
 }
 
-// TextArea returns a field div containing a textarea
-func TextArea(label string, name string, v interface{}, args ...string) got.HTML {
-	attributes := ""
-	if len(args) > 0 {
-		attributes = strings.Join(args, " ")
+// formatTime formats the time in the layout and location given (if not nil), zero times are formatted as blank
+func formatTime(t time.Time, layout string, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format(layout)
+}
+
+// parseTime parses the value in the first of the layouts which matches, in the zone given or UTC,
+// blank values return a zero time
+func parseTime(s string, zone []interface{}, layouts ...string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
 	}
 
-	fieldTemplate :=
-		`<div class="field">
-       <label>%s</label>
-       <textarea name="%s" %s>%v</textarea>
-       </div>`
-	output := fmt.Sprintf(fieldTemplate,
-		Escape(label),
-		Escape(name),
-		attributes, // NB we do not escape attributes, which may contain HTML
-		v)          // NB value may contain HTML
+	loc := time.UTC
+	for _, z := range zone {
+		if l := zoneLocation(z); l != nil {
+			loc = l
+		}
+	}
 
-	return got.HTML(output)
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("helpers: invalid time %q", s)
+}
+
+// TextArea renders the forms/textarea.html.got partial containing a textarea
+func TextArea(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderTextArea(label, name, v, errors, args)
+}
+
+// renderTextArea renders the forms/textarea.html.got partial with validation errors for the field
+func renderTextArea(label interface{}, name string, v interface{}, errors []string, args []interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	return Partial("forms/textarea.html.got", fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"value":      fmt.Sprintf("%v", v),
+		"attributes": attributes.HTMLAttr(),
+	}))
 }
 
-// TODO flip the select helpers to use Selectable all the time?
-// Redefine concrete type Option as a Selectable and this should be doable?
+// fieldContext adds the name and validation errors for a field to the partial context given
+func fieldContext(name string, errors []string, context map[string]interface{}) map[string]interface{} {
+	context["name"] = name
+	context["errors"] = errors
+	context["errorID"] = name + "_error"
+	return context
+}
 
-// Selectable provides an interface for options in a select
+// Selectable provides an interface for options in a select, options may also implement
+// SelectableGroup and SelectableDisabled
 type Selectable interface {
 	SelectName() string
 	SelectValue() string
 }
 
+// SelectableGroup is implemented by options which belong to a group, options are rendered in an optgroup for each group
+type SelectableGroup interface {
+	SelectGroup() string
+}
+
+// SelectableDisabled is implemented by options which may be disabled
+type SelectableDisabled interface {
+	SelectDisabled() bool
+}
+
 // SelectableOption provides a concrete implementation of Selectable - this should be called string option or similar
 type SelectableOption struct {
-	Name  string
-	Value string
+	Name     string
+	Value    string
+	Group    string
+	Disabled bool
 }
 
 // SelectName returns the public name for this select option
@@ -115,13 +236,23 @@ func (o SelectableOption) SelectValue() string {
 	return o.Value
 }
 
+// SelectGroup returns the group for this select option, if any
+func (o SelectableOption) SelectGroup() string {
+	return o.Group
+}
+
+// SelectDisabled returns true if this select option is disabled
+func (o SelectableOption) SelectDisabled() bool {
+	return o.Disabled
+}
+
 // StringOptions creates an array of selectables from strings
 func StringOptions(args ...string) []SelectableOption {
 	var options []SelectableOption
 	// Construct a slice of options from these strings
 
 	for _, s := range args {
-		options = append(options, SelectableOption{s, s})
+		options = append(options, SelectableOption{Name: s, Value: s})
 	}
 
 	return options
@@ -147,83 +278,202 @@ func NumberOptions(args ...int64) []SelectableOption {
 		v := strconv.Itoa(int(i))
 		n := v
 
-		options = append(options, SelectableOption{n, v})
+		options = append(options, SelectableOption{Name: n, Value: v})
 	}
 
 	return options
 }
 
-// Better to use an interface and not reflect here - Would rather avoid use of reflect...
-
-// OptionsForSelect creates a select field given an array of keys and values in order
-func OptionsForSelect(value interface{}, options interface{}) got.HTML {
-
-	stringValue := fmt.Sprintf("%v", value)
-
-	output := ""
+// SelectOptions returns options for a slice of structs, pointers or maps, reading the value, name and
+// optionally the group of each option from the fields, map keys or methods named,
+// e.g. {{select "Author" "author_id" .page.AuthorID (selectoptions .users "ID" "Name")}}
+func SelectOptions(list interface{}, fields ...string) ([]Selectable, error) {
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("helpers: select options require value and name fields")
+	}
 
-	switch reflect.TypeOf(options).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(options)
-		for i := 0; i < s.Len(); i++ {
-			o := s.Index(i).Interface().(Selectable)
-			sel := ""
-			if o.SelectValue() == stringValue {
-				sel = "selected"
+	var options []Selectable
+	for _, item := range toList(list) {
+		values := make([]string, 3)
+		for i, f := range fields {
+			v, err := fieldValue(item, f)
+			if err != nil {
+				return nil, err
 			}
+			values[i] = fmt.Sprintf("%v", v)
+		}
+		options = append(options, SelectableOption{Value: values[0], Name: values[1], Group: values[2]})
+	}
+	return options, nil
+}
 
-			output += fmt.Sprintf(`<option value="%s" %s>%s</option>
-`, o.SelectValue(), sel, Escape(o.SelectName()))
+// selectables returns the options given as a slice of Selectable, options may be a slice of Selectable,
+// a slice of strings or numbers, or a map of values to names, which is sorted by name
+func selectables(options interface{}) ([]Selectable, error) {
+	if options == nil {
+		return nil, nil
+	}
+	if s, ok := options.([]Selectable); ok {
+		return s, nil
+	}
 
+	var list []Selectable
+	v := reflect.ValueOf(options)
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			list = append(list, SelectableOption{
+				Value: fmt.Sprintf("%v", k.Interface()),
+				Name:  fmt.Sprintf("%v", v.MapIndex(k).Interface()),
+			})
 		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].SelectName() == list[j].SelectName() {
+				return list[i].SelectValue() < list[j].SelectValue()
+			}
+			return list[i].SelectName() < list[j].SelectName()
+		})
+	case reflect.Slice, reflect.Array:
+		for _, item := range toList(options) {
+			switch o := item.(type) {
+			case Selectable:
+				list = append(list, o)
+			case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+				list = append(list, SelectableOption{Name: fmt.Sprintf("%v", o), Value: fmt.Sprintf("%v", o)})
+			default:
+				return nil, fmt.Errorf("helpers: option %v is not Selectable", item)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("helpers: invalid options %v", options)
 	}
-
-	return got.HTML(output)
+	return list, nil
 }
 
-// SelectArray creates a select field given an array of keys and values in order
-func SelectArray(label string, name string, value interface{}, options interface{}) got.HTML {
-
-	stringValue := fmt.Sprintf("%v", value)
+// selectOption is an option passed to form partials
+type selectOption struct {
+	Value    string
+	Name     string
+	Selected bool
+	Disabled bool
+}
 
-	tmpl :=
-		`<div class="field">
-      <label>%s</label>
-      <select type="select" name="%s" id="%s">
-      %s
-      </select>
-      </div>`
+// selectGroup is a group of options passed to form partials, options which are not grouped have no label
+type selectGroup struct {
+	Label   string
+	Options []selectOption
+}
 
-	if label == "" {
-		tmpl = `%s<select type="select" name="%s" id="%s">
-%s
-</select>`
+// selectedValues returns the values selected given a value or a slice of values, and true if a slice was given
+func selectedValues(value interface{}) (map[string]bool, bool) {
+	selected := make(map[string]bool)
+	v := reflect.ValueOf(value)
+	if (v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8) || v.Kind() == reflect.Array {
+		for _, item := range toList(value) {
+			selected[fmt.Sprintf("%v", item)] = true
+		}
+		return selected, true
+	}
+	if value != nil {
+		selected[fmt.Sprintf("%v", value)] = true
 	}
+	return selected, false
+}
 
-	opts := ""
+// selectOptions adds the options and groups for the options given to the partial context,
+// with the value or slice of values given selected, and multiple set if a slice of values is given
+func selectOptions(value interface{}, options interface{}, context map[string]interface{}) (map[string]interface{}, error) {
+	list, err := selectables(options)
+	if err != nil {
+		return nil, err
+	}
+	selected, multiple := selectedValues(value)
+
+	var opts []selectOption
+	var groups []selectGroup
+	for _, o := range list {
+		opt := selectOption{
+			Value:    o.SelectValue(),
+			Name:     o.SelectName(),
+			Selected: selected[o.SelectValue()],
+		}
+		if d, ok := o.(SelectableDisabled); ok {
+			opt.Disabled = d.SelectDisabled()
+		}
+		opts = append(opts, opt)
 
-	switch reflect.TypeOf(options).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(options)
-		for i := 0; i < s.Len(); i++ {
-			o := s.Index(i).Interface().(Selectable)
-			sel := ""
-			if o.SelectValue() == stringValue {
-				sel = "selected"
+		label := ""
+		if g, ok := o.(SelectableGroup); ok {
+			label = g.SelectGroup()
+		}
+		// Options are added to the group with the same label if any, so groups need not be sorted
+		i := len(groups)
+		for j, g := range groups {
+			if g.Label == label {
+				i = j
 			}
+		}
+		if i == len(groups) {
+			groups = append(groups, selectGroup{Label: label})
+		}
+		groups[i].Options = append(groups[i].Options, opt)
+	}
 
-			opts += fmt.Sprintf(`<option value="%s" %s>%s</option>
-`, o.SelectValue(), sel, Escape(o.SelectName()))
+	context["options"] = opts
+	context["groups"] = groups
+	context["multiple"] = multiple
+	return context, nil
+}
 
-		}
+// OptionsForSelect renders the forms/options.html.got partial given a value or slice of values and options,
+// the args are attributes set on each option
+func OptionsForSelect(value interface{}, options interface{}, args ...interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	context, err := selectOptions(value, options, map[string]interface{}{
+		"optionAttributes": attributes.HTMLAttr(),
+	})
+	if err != nil {
+		return "", err
 	}
+	return Partial("forms/options.html.got", context)
+}
 
-	output := fmt.Sprintf(tmpl, Escape(label), Escape(name), Escape(name), opts)
+// Select renders the forms/select.html.got partial given a value and options, which may be a slice of Selectable,
+// a slice of strings or numbers, or a map of values to names (see also SelectOptions).
+// If the value is a slice of values the select allows multiple selections.
+// The args may be the render context or FormErrors, to show validation errors for the field, and attributes.
+func Select(label interface{}, name string, value interface{}, options interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderSelect(label, name, value, options, errors, args)
+}
 
-	return got.HTML(output)
+// SelectArray renders the forms/select.html.got partial given a value and a slice of Selectable,
+// it is deprecated, use Select instead
+func SelectArray(label interface{}, name string, value interface{}, options interface{}, args ...interface{}) (got.HTML, error) {
+	return renderSelect(label, name, value, options, nil, args)
 }
 
-// FIXME - make Option conform to Selectable interface and use that instead of concrete type below
+// renderSelect renders the forms/select.html.got partial with validation errors for the field
+func renderSelect(label interface{}, name string, value interface{}, options interface{}, errors []string, args []interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	// The partial sets the type
+	attributes.remove("type")
+	context, err := selectOptions(value, options, fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"id":         name,
+		"attributes": attributes.HTMLAttr(),
+	}))
+	if err != nil {
+		return "", err
+	}
+	return Partial("forms/select.html.got", context)
+}
 
 // Option type contains number and string
 type Option struct {
@@ -261,37 +511,3 @@ func (o Option) SetID(id int64) {
 func NewOption(id int64, name string) Option {
 	return Option{Id: id, Name: name}
 }
-
-// Select creates a select field given an array of keys and values in order
-func Select(label string, name string, value int64, options []Option) got.HTML {
-
-	tmpl :=
-		`<div class="field">
-      <label>%s</label>
-      <select type="select" name="%s">
-      %s
-      </select>
-      </div>`
-
-	if label == "" {
-		tmpl = `%s<select type="select" name="%s">
-      %s
-      </select>`
-	}
-
-	opts := ""
-	for _, o := range options {
-
-		s := ""
-		if o.Id == value {
-			s = "selected"
-		}
-
-		opts += fmt.Sprintf(`<option value="%d" %s>%s</option>
-`, o.Id, s, Escape(o.Name))
-	}
-
-	output := fmt.Sprintf(tmpl, Escape(label), Escape(name), opts)
-
-	return got.HTML(output)
-}
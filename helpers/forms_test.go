@@ -0,0 +1,307 @@
+package helpers
+
+import (
+	got "html/template"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFormFields tests form fields rendered with the default partials
+func TestFormFields(t *testing.T) {
+	r, err := Field("Name", "name", "<b>")
+	assertHTML(t, "field", r, err, `<label>Name</label>`, `<input name="name" value="&lt;b&gt;" type="text">`)
+	r, err = Field("", "name", 1, `type="number"`)
+	assertHTML(t, "field no label", r, err, `<input name="name" value="1" type="number">`)
+	r, err = DateField("Date", "date", time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC))
+	assertHTML(t, "datefield", r, err, `value="Mar 4, 2020" data-date="2020-03-04"`)
+	r, err = TextArea("Text", "text", "a & b", `rows="3"`)
+	assertHTML(t, "textarea", r, err, `<textarea name="text" rows="3">a &amp; b</textarea>`)
+	r, err = OptionsForSelect("b", StringOptions("a", "b"))
+	assertHTML(t, "options", r, err, `<option value="a" >a</option>`, `<option value="b" selected>b</option>`)
+	r, err = SelectArray("Pick", "pick", 2, NumberOptions(1, 2))
+	assertHTML(t, "selectarray", r, err, `<select type="select" name="pick" id="pick">`, `<option value="2" selected>2</option>`)
+	r, err = Select("", "pick", 1, []Option{NewOption(1, "One"), NewOption(2, "Two")})
+	assertHTML(t, "select", r, err, `<select type="select" name="pick" id="pick">`, `<option value="1" selected>One</option>`, `<option value="2" >Two</option>`)
+
+	// Selects accept attributes like other fields, set on the select, or on each option for OptionsForSelect
+	a, _ := NewAttrs("class", "wide", "type", "text")
+	r, err = Select("Pick", "pick", 1, NumberOptions(1, 2), a, `required`)
+	assertHTML(t, "select attrs", r, err, `<select type="select" name="pick" id="pick" class="wide" required>`)
+	r, err = Select("", "pick", 1, NumberOptions(1, 2), `data-x="y"`)
+	assertHTML(t, "select no label attrs", r, err, `<select type="select" name="pick" id="pick" data-x="y">`)
+	r, err = SelectMultiple("Tags", "tags", []int64{1}, NumberOptions(1, 2), `size="3"`)
+	assertHTML(t, "selectmultiple attrs", r, err, `<select type="select" name="tags" id="tags" multiple size="3">`)
+	r, err = SelectArray("Pick", "pick", 2, NumberOptions(1, 2), `disabled`)
+	assertHTML(t, "selectarray attrs", r, err, `<select type="select" name="pick" id="pick" disabled>`)
+	r, err = OptionsForSelect("b", StringOptions("a", "b"), `class="opt"`)
+	assertHTML(t, "options attrs", r, err, `<option value="a"  class="opt">a</option>`, `<option value="b" selected class="opt">b</option>`)
+	f, err := Form(map[string]interface{}{"Status": 1, "Tags": []int64{2}}, "/pages")
+	if err != nil {
+		t.Fatalf("form failed err:%s", err)
+	}
+	r, err = f.Select("Status", NumberOptions(0, 1), `class="wide"`)
+	assertHTML(t, "form select attrs", r, err, `<select type="select" name="status" id="status" class="wide">`)
+	r, err = f.SelectMultiple("Tags", NumberOptions(1, 2), `class="wide"`)
+	assertHTML(t, "form selectmultiple attrs", r, err, `id="tags" multiple class="wide">`)
+}
+
+// userOption is a struct used to test select options read from fields
+type userOption struct {
+	ID    int64
+	Name  string
+	Admin bool
+}
+
+// Role returns the role of the user
+func (u *userOption) Role() string {
+	if u.Admin {
+		return "Admins"
+	}
+	return "Users"
+}
+
+// TestSelectOptions tests selects with grouped, disabled and generic options
+func TestSelectOptions(t *testing.T) {
+	users := []*userOption{{1, "Alice", true}, {2, "Bob", false}, {3, "Carol", true}}
+	userOptions, err := SelectOptions(users, "ID", "Name", "Role")
+	if err != nil {
+		t.Fatalf("select options failed err:%s", err)
+	}
+
+	r, err := Select("", "user_id", int64(2), userOptions)
+	assertHTML(t, "groups", r, err, "<optgroup label=\"Admins\">\n<option value=\"1\" >Alice</option>\n<option value=\"3\" >Carol</option>\n</optgroup>", "<optgroup label=\"Users\">\n<option value=\"2\" selected>Bob</option>\n</optgroup>")
+	r, err = Select("Size", "size", "m", []SelectableOption{{Name: "S", Value: "s", Disabled: true}, {Name: "M", Value: "m"}})
+	assertHTML(t, "disabled", r, err, `<option value="s"  disabled>S</option>`, `<option value="m" selected>M</option>`)
+	r, err = Select("", "status", 1, map[int]string{0: "Draft", 1: "Published", 2: "Archived"})
+	assertHTML(t, "map", r, err, "<option value=\"2\" >Archived</option>\n<option value=\"0\" >Draft</option>\n<option value=\"1\" selected>Published</option>")
+	r, err = Select("", "color", "blue", []string{"red", "blue"})
+	assertHTML(t, "strings", r, err, `<option value="blue" selected>blue</option>`)
+	r, err = Select("Tags", "tags", []string{"a", "c"}, []string{"a", "b", "c"})
+	assertHTML(t, "multiple", r, err, ` multiple>`, `<option value="a" selected>a</option>`, `<option value="b" >b</option>`, `<option value="c" selected>c</option>`)
+	r, err = SelectMultiple("Tags", "tags", "b", []string{"a", "b"})
+	assertHTML(t, "selectmultiple single", r, err, ` multiple>`, `<option value="b" selected>b</option>`)
+	r, err = RadioGroup("Size", "size", "", []SelectableOption{{Name: "S", Value: "s", Disabled: true}})
+	assertHTML(t, "radiogroup disabled", r, err, `value="s" disabled >`)
+
+	// Invalid options return errors rather than panicking
+	invalid := []interface{}{[]interface{}{struct{}{}}, "options", 1}
+	for _, options := range invalid {
+		if _, err := Select("", "x", "", options); err == nil {
+			t.Errorf("select invalid options %v failed", options)
+		}
+	}
+	if _, err := SelectOptions(users, "ID"); err == nil {
+		t.Errorf("select options missing name failed")
+	}
+	if _, err := SelectOptions(users, "ID", "Missing"); err == nil {
+		t.Errorf("select options missing field failed")
+	}
+}
+
+// TestPartialOverride tests app partials replace the default partials
+func TestPartialOverride(t *testing.T) {
+	defer func(r func(string, map[string]interface{}) (got.HTML, bool, error)) { PartialRenderer = r }(PartialRenderer)
+
+	PartialRenderer = func(path string, context map[string]interface{}) (got.HTML, bool, error) {
+		if path == "forms/field.html.got" {
+			return got.HTML("<div class=\"mb-3\">" + Escape(context["name"].(string)) + "</div>"), true, nil
+		}
+		return "", false, nil
+	}
+
+	r, err := Field("Name", "name", "")
+	if err != nil || r != `<div class="mb-3">name</div>` {
+		t.Errorf("partial override failed got:%s %v", r, err)
+	}
+
+	// Partials the app does not define use the default
+	r, err = TextArea("Text", "text", "")
+	if err != nil || !strings.Contains(string(r), "<textarea") {
+		t.Errorf("partial default failed got:%s %v", r, err)
+	}
+
+	if _, err = Partial("forms/missing.html.got", nil); err == nil {
+		t.Errorf("partial missing failed")
+	}
+}
+
+// TestCSRF tests authenticity token helpers read the render context
+func TestCSRF(t *testing.T) {
+	context := map[string]interface{}{"authenticity_token": `a"b`}
+	r, err := CSRF(context)
+	if err != nil || r != `<input type="hidden" name="authenticity_token" value="a&#34;b">` {
+		t.Errorf("csrf failed got:%s %v", r, err)
+	}
+	r, err = CSRFMeta(context)
+	if err != nil || r != `<meta name="csrf-token" content="a&#34;b">` {
+		t.Errorf("csrfmeta failed got:%s %v", r, err)
+	}
+	if _, err = CSRF(map[string]interface{}{}); err == nil {
+		t.Errorf("csrf missing token failed")
+	}
+	if _, err = CSRF(); err == nil {
+		t.Errorf("csrf without context failed")
+	}
+}
+
+// assertHTML checks that h was rendered without error and contains each of the strings wanted
+func assertHTML(t *testing.T, name string, h got.HTML, err error, want ...string) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s failed err:%s", name, err)
+	}
+	for _, w := range want {
+		if !strings.Contains(string(h), w) {
+			t.Errorf("%s failed got:%s want:%s", name, h, w)
+		}
+	}
+}
+
+// FuzzForms tests that user input given to the form helpers cannot inject elements or attributes,
+// and that values are rendered unchanged once parsed
+func FuzzForms(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	fieldTags := []string{"div", "label", "input", "span"}
+	fieldAttributes := []string{"class", "name", "id", "value", "type", "rows", "data-date", "autocomplete"}
+	selectTags := []string{"div", "label", "select", "option"}
+	selectAttributes := []string{"name", "id", "value", "type", "selected", "class"}
+	f.Fuzz(func(t *testing.T, s string) {
+		r, err := Field(s, s, s)
+		if err != nil {
+			t.Fatalf("field %q failed %s", s, err)
+		}
+		tokens := parseTokens(t, string(r), fieldTags, fieldAttributes)
+		if v, _ := attribute(tokens, "value"); roundTrips(s) && (v != s || text(tokens) != s) {
+			t.Fatalf("field %q got:%q", s, r)
+		}
+
+		r, err = DateField(s, s, time.Now())
+		if err != nil {
+			t.Fatalf("datefield %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), fieldTags, fieldAttributes)
+
+		r, err = TextArea(s, s, s, `rows="3"`)
+		if err != nil {
+			t.Fatalf("textarea %q failed %s", s, err)
+		}
+		tokens = parseTokens(t, string(r), []string{"div", "label", "textarea", "span"}, fieldAttributes)
+		if roundTrips(s) && text(tokens) != s+s {
+			t.Fatalf("textarea %q got:%q", s, r)
+		}
+
+		options := []SelectableOption{{Name: s, Value: s}, {Name: "x", Value: "x"}}
+		r, err = OptionsForSelect(s, options)
+		if err != nil {
+			t.Fatalf("options %q failed %s", s, err)
+		}
+		tokens = parseTokens(t, string(r), []string{"option"}, selectAttributes)
+		if v, _ := attribute(tokens, "value"); roundTrips(s) && v != s {
+			t.Fatalf("options %q got:%q", s, r)
+		}
+
+		r, err = SelectArray(s, s, s, options)
+		if err != nil {
+			t.Fatalf("selectarray %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), selectTags, selectAttributes)
+
+		r, err = Select(s, s, 1, []Option{NewOption(1, s)})
+		if err != nil {
+			t.Fatalf("select %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), selectTags, selectAttributes)
+
+		r, err = Checkbox(s, s, true)
+		if err != nil {
+			t.Fatalf("checkbox %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), fieldTags, append(fieldAttributes, "checked"))
+
+		r, err = RadioGroup(s, s, s, options)
+		if err != nil {
+			t.Fatalf("radiogroup %q failed %s", s, err)
+		}
+		tokens = parseTokens(t, string(r), []string{"fieldset", "legend", "label", "input", "span"}, append(fieldAttributes, "checked"))
+		if v, _ := attribute(tokens, "value"); roundTrips(s) && v != s {
+			t.Fatalf("radiogroup %q got:%q", s, r)
+		}
+
+		r, err = SelectMultiple(s, s, []string{s}, options)
+		if err != nil {
+			t.Fatalf("selectmultiple %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), selectTags, append(selectAttributes, "multiple"))
+
+		r, err = NumberField(s, s, 1, 0, nil, "0.5")
+		if err != nil {
+			t.Fatalf("numberfield %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), fieldTags, append(fieldAttributes, "min", "step"))
+
+		for _, field := range []func(interface{}, string, interface{}, ...interface{}) (got.HTML, error){EmailField, TelField, URLField} {
+			r, err = field(s, s, s)
+			if err != nil {
+				t.Fatalf("field %q failed %s", s, err)
+			}
+			tokens = parseTokens(t, string(r), fieldTags, fieldAttributes)
+			if v, _ := attribute(tokens, "value"); roundTrips(s) && v != s {
+				t.Fatalf("field %q got:%q", s, r)
+			}
+		}
+
+		r, err = HiddenField(s, s)
+		if err != nil {
+			t.Fatalf("hiddenfield %q failed %s", s, err)
+		}
+		parseTokens(t, string(r), fieldTags, fieldAttributes)
+
+		r, err = FileField(s, s, s)
+		if err != nil {
+			t.Fatalf("filefield %q failed %s", s, err)
+		}
+		tokens = parseTokens(t, string(r), fieldTags, append(fieldAttributes, "accept"))
+		if v, _ := attribute(tokens, "accept"); roundTrips(s) && v != s {
+			t.Fatalf("filefield %q got:%q", s, r)
+		}
+
+		for _, field := range []func(interface{}, string, time.Time, ...interface{}) (got.HTML, error){DateTimeField, TimeField} {
+			r, err = field(s, s, time.Now())
+			if err != nil {
+				t.Fatalf("timefield %q failed %s", s, err)
+			}
+			parseTokens(t, string(r), fieldTags, fieldAttributes)
+		}
+
+		r, err = CSRF(s)
+		if err == nil {
+			tokens = parseTokens(t, string(r), []string{"input"}, []string{"type", "name", "value"})
+			if v, _ := attribute(tokens, "value"); roundTrips(s) && v != s {
+				t.Fatalf("csrf %q got:%q", s, r)
+			}
+		}
+		r, err = CSRFMeta(s)
+		if err == nil {
+			parseTokens(t, string(r), []string{"meta"}, []string{"name", "content"})
+		}
+	})
+}
+
+// TestTrustedHTML tests values are always escaped and labels are escaped unless they are trusted html
+func TestTrustedHTML(t *testing.T) {
+	r, err := TextArea("<b>Text</b>", "text", "</textarea><script>")
+	if err != nil || !strings.Contains(string(r), `<label>&lt;b&gt;Text&lt;/b&gt;</label>`) || !strings.Contains(string(r), `>&lt;/textarea&gt;&lt;script&gt;</textarea>`) {
+		t.Errorf("textarea escape failed got:%s %v", r, err)
+	}
+	r, err = Checkbox(HTML(`I agree to the <a href="/terms">terms</a>`), "terms", false)
+	if err != nil || !strings.Contains(string(r), `> I agree to the <a href="/terms">terms</a></label>`) {
+		t.Errorf("checkbox trusted label failed got:%s %v", r, err)
+	}
+	r, err = Field(HTML("<b>Name</b>"), "name", HTML("<b>"))
+	if err != nil || !strings.Contains(string(r), `<label><b>Name</b></label>`) || !strings.Contains(string(r), `value="&lt;b&gt;"`) {
+		t.Errorf("field trusted label failed got:%s %v", r, err)
+	}
+}
@@ -31,10 +31,11 @@ func TestPrices(t *testing.T) {
 
 	var pence int
 	var price string
+	var err error
 
 	price = "£10.00"
-	pence = PriceToCents(price)
-	if pence != 1000 {
+	pence, err = PriceToCents(price)
+	if err != nil || pence != 1000 {
 		t.Fatalf(Format, price, "1000", fmt.Sprintf("%d", pence))
 	}
 	price = CentsToPrice(int64(pence))
@@ -43,8 +44,8 @@ func TestPrices(t *testing.T) {
 	}
 
 	price = "10"
-	pence = PriceToCents(price)
-	if pence != 1000 {
+	pence, err = PriceToCents(price)
+	if err != nil || pence != 1000 {
 		t.Fatalf(Format, price, "1000", fmt.Sprintf("%d", pence))
 	}
 	price = CentsToPrice(int64(pence))
@@ -53,8 +54,8 @@ func TestPrices(t *testing.T) {
 	}
 
 	price = "45"
-	pence = PriceToCents(price)
-	if pence != 4500 {
+	pence, err = PriceToCents(price)
+	if err != nil || pence != 4500 {
 		t.Fatalf(Format, price, "4500", fmt.Sprintf("%d", pence))
 	}
 	price = CentsToPrice(int64(pence))
@@ -63,8 +64,8 @@ func TestPrices(t *testing.T) {
 	}
 
 	price = "45.35"
-	pence = PriceToCents(price)
-	if pence != 4535 {
+	pence, err = PriceToCents(price)
+	if err != nil || pence != 4535 {
 		t.Fatalf(Format, price, "4535", fmt.Sprintf("%d", pence))
 	}
 
@@ -74,8 +75,8 @@ func TestPrices(t *testing.T) {
 	}
 
 	price = "45.30"
-	pence = PriceToCents(price)
-	if pence != 4530 {
+	pence, err = PriceToCents(price)
+	if err != nil || pence != 4530 {
 		t.Fatalf(Format, price, "4530", fmt.Sprintf("%d", pence))
 	}
 
@@ -2,7 +2,6 @@ package helpers
 
 import (
 	"fmt"
-	"strings"
 
 	got "html/template"
 
@@ -29,14 +28,19 @@ func EscapeURL(s string) string {
 	return got.URLQueryEscaper(s)
 }
 
-// Link returns got.HTML with an anchor link given text and URL required
-// Attributes (if supplied) should not contain user input
-func Link(t string, u string, a ...string) got.HTML {
-	attributes := ""
-	if len(a) > 0 {
-		attributes = strings.Join(a, " ")
+// Link returns got.HTML with an anchor link given text and URL required,
+// attributes given as strings must not contain user input, use Attrs for attributes which may.
+// The URL is the only href, an error is returned if the attributes contain another.
+func Link(t string, u string, a ...interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(a)
+	if err != nil {
+		return "", err
+	}
+	if attributes.Has("href") {
+		return "", fmt.Errorf("helpers: link to %s given a second href", u)
 	}
-	return got.HTML(fmt.Sprintf("<a href=\"%s\" %s>%s</a>", Escape(u), Escape(attributes), Escape(t)))
+	href := &Attrs{list: []attr{{name: "href", value: u}}}
+	return got.HTML(fmt.Sprintf("<a %s>%s</a>", href.Merge(attributes).String(), Escape(t))), nil
 }
 
 // HTML returns a string (which must not contain user input) as go template HTML
@@ -59,14 +63,24 @@ func Strip(s string) got.HTML {
 	return got.HTML(sanitize.HTML(s))
 }
 
-// Sanitize the html, leaving only tags we consider safe (see the sanitize package for details and tests)
-func Sanitize(s string) got.HTML {
-	s, err := sanitize.HTMLAllowing(s)
+// Sanitize the html using the named policy (see RegisterPolicy), or DefaultPolicy if only html is given,
+// e.g. {{sanitize "comment" .Body}} or {{sanitize .Body}}
+func Sanitize(args ...string) (got.HTML, error) {
+	name, s := "default", ""
+	switch len(args) {
+	case 1:
+		s = args[0]
+	case 2:
+		name, s = args[0], args[1]
+	default:
+		return "", fmt.Errorf("helpers: sanitize requires a policy name and html")
+	}
+
+	h, err := SanitizeHTML(name, s)
 	if err != nil {
-		fmt.Printf("#error sanitizing html:%s", err)
-		return got.HTML("")
+		return "", err
 	}
-	return got.HTML(s)
+	return got.HTML(h), nil
 }
 
 // XMLPreamble returns an XML preamble as got.HTML,
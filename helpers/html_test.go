@@ -0,0 +1,145 @@
+package helpers
+
+import (
+	got "html/template"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// TestLink tests links escape the url and text
+func TestLink(t *testing.T) {
+	r, err := Link("<b>Home</b>", `/?a=1&b="2"`, `rel="nofollow"`)
+	if err != nil || r != `<a href="/?a=1&amp;b=&#34;2&#34;" rel="nofollow">&lt;b&gt;Home&lt;/b&gt;</a>` {
+		t.Errorf("link failed got:%s %v", r, err)
+	}
+	r, err = Link("x", "javascript:alert(1)")
+	if err != nil || r != `<a href="#">x</a>` {
+		t.Errorf("link unsafe url failed got:%s %v", r, err)
+	}
+	for _, a := range []interface{}{`href="/other"`, got.HTMLAttr(`rel="nofollow" href="/other"`), Attrs{list: []attr{{name: "href", value: "/other"}}}} {
+		r, err = Link("x", "/", a)
+		if err == nil {
+			t.Errorf("link second href %v failed got:%s", a, r)
+		}
+	}
+}
+
+// FuzzHTML tests that user input given to the html helpers cannot inject elements or attributes
+func FuzzHTML(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		tokens := parseTokens(t, Escape(s), nil, nil)
+		if roundTrips(s) && text(tokens) != s {
+			t.Fatalf("escape %q got:%q", s, text(tokens))
+		}
+
+		parseTokens(t, string(Strip(s)), nil, nil)
+
+		h, err := Sanitize(s)
+		if err != nil {
+			t.Fatalf("sanitize %q failed %s", s, err)
+		}
+		for _, token := range parseTokens(t, string(h), DefaultPolicy.Tags, DefaultPolicy.Attributes) {
+			for _, a := range token.Attr {
+				if urlAttributes[a.Key] && !DefaultPolicy.allowedURL(a.Val) {
+					t.Fatalf("sanitize %q allowed url %q", s, a.Val)
+				}
+			}
+		}
+
+		tokens = parseTokens(t, string(Style(s)), []string{"link"}, []string{"href", "media", "rel", "type"})
+		if len(tokens) != 1 {
+			t.Fatalf("style %q injected:%v", s, tokens)
+		}
+		tokens = parseTokens(t, string(Script(s)), []string{"script"}, []string{"src", "type"})
+		if len(tokens) != 2 {
+			t.Fatalf("script %q injected:%v", s, tokens)
+		}
+
+		l, err := Link(s, s)
+		if err != nil {
+			t.Fatalf("link %q failed %s", s, err)
+		}
+		tokens = parseTokens(t, string(l), []string{"a"}, []string{"href"})
+		if roundTrips(s) && text(tokens) != s {
+			t.Fatalf("link %q got:%q", s, text(tokens))
+		}
+	})
+}
+
+// fuzzSeeds are the seed inputs for fuzz tests of helpers
+var fuzzSeeds = []string{
+	"hello",
+	"a & b",
+	`"><script>alert(1)</script>`,
+	"' onmouseover='alert(1)",
+	"</textarea><script>alert(1)</script>",
+	"</option></select><img src=x onerror=alert(1)>",
+	"javascript:alert(1)",
+	"<a href=\"javascript:alert(1)\">x</a>",
+	"&lt;b&gt; &#34;",
+	"<!-- comment --><![CDATA[x]]>",
+}
+
+// parseTokens parses html and returns the tokens, failing if it contains start tags other than those given,
+// or attributes other than those given
+func parseTokens(t *testing.T, s string, tags []string, attributes []string) []html.Token {
+	var tokens []html.Token
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		if z.Next() == html.ErrorToken {
+			return tokens
+		}
+		token := z.Token()
+		switch token.Type {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if !includes(tags, token.Data) {
+				t.Fatalf("parse %q found tag %s", s, token.Data)
+			}
+			for _, a := range token.Attr {
+				if !includes(attributes, a.Key) {
+					t.Fatalf("parse %q found attribute %s", s, a.Key)
+				}
+			}
+		case html.EndTagToken:
+			if !includes(tags, token.Data) {
+				t.Fatalf("parse %q found end tag %s", s, token.Data)
+			}
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+// text returns the text of the tokens given, ignoring space between tags
+func text(tokens []html.Token) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		if token.Type == html.TextToken && strings.TrimSpace(token.Data) != "" {
+			b.WriteString(token.Data)
+		}
+	}
+	return b.String()
+}
+
+// attribute returns the value of the attribute key in the first token which has it
+func attribute(tokens []html.Token, key string) (string, bool) {
+	for _, token := range tokens {
+		for _, a := range token.Attr {
+			if a.Key == key {
+				return a.Val, true
+			}
+		}
+	}
+	return "", false
+}
+
+// roundTrips returns true if s is unchanged by parsing html, which replaces null characters,
+// normalises newlines and invalid utf8, and drops space only text between tags
+func roundTrips(s string) bool {
+	return utf8.ValidString(s) && !strings.ContainsAny(s, "\x00\r") && strings.TrimSpace(s) == s && s != ""
+}
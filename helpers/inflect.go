@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// inflection is a regexp rule and replacement used to pluralize or singularize english words
+type inflection struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Rules are applied in order, the first matching rule is used
+var pluralRules = inflections([][2]string{
+	{`(quiz)$`, "${1}zes"},
+	{`^(ox)$`, "${1}en"},
+	{`(matr|vert|ind)(ix|ex)$`, "${1}ices"},
+	{`(x|ch|ss|sh|zz)$`, "${1}es"},
+	{`([^aeiouy]|qu)y$`, "${1}ies"},
+	{`(hive)$`, "${1}s"},
+	{`(?:([^f])fe|([lr])f)$`, "${1}${2}ves"},
+	{`sis$`, "ses"},
+	{`([ti])um$`, "${1}a"},
+	{`(buffal|tomat|potat|her)o$`, "${1}oes"},
+	{`(bu|gas)s$`, "${1}ses"},
+	{`(alias|status|campus)$`, "${1}es"},
+	{`(octop|vir)us$`, "${1}i"},
+	{`s$`, "s"},
+	{`$`, "s"},
+})
+
+var singularRules = inflections([][2]string{
+	{`(quiz)zes$`, "${1}"},
+	{`(matr)ices$`, "${1}ix"},
+	{`(vert|ind)ices$`, "${1}ex"},
+	{`^(ox)en$`, "${1}"},
+	{`(alias|status|campus)es$`, "${1}"},
+	{`(octop|vir)i$`, "${1}us"},
+	{`(cris|ax|test)es$`, "${1}is"},
+	{`(bu|gas)ses$`, "${1}s"},
+	{`(x|ch|ss|sh|zz)es$`, "${1}"},
+	{`(buffal|tomat|potat|her)oes$`, "${1}o"},
+	{`(hive)s$`, "${1}"},
+	{`([^aeiouy]|qu)ies$`, "${1}y"},
+	{`([lr])ves$`, "${1}f"},
+	{`([^f])ves$`, "${1}fe"},
+	{`(analy|ba|diagno|parenthe|progno|synop|the)ses$`, "${1}sis"},
+	{`([ti])a$`, "${1}um"},
+	{`ss$`, "ss"},
+	{`s$`, ""},
+})
+
+// irregulars maps irregular singular words to their plurals
+var irregulars = map[string]string{
+	"child":  "children",
+	"foot":   "feet",
+	"goose":  "geese",
+	"man":    "men",
+	"mouse":  "mice",
+	"person": "people",
+	"tooth":  "teeth",
+	"woman":  "women",
+}
+
+// uncountables are words with the same singular and plural form
+var uncountables = map[string]bool{
+	"equipment": true, "fish": true, "information": true, "money": true, "news": true,
+	"rice": true, "series": true, "sheep": true, "species": true, "deer": true,
+}
+
+// Pluralize returns the english plural of a word, or the word itself if an optional count of 1 is given,
+// e.g. {{.count}} {{pluralize "page" .count}}
+func Pluralize(word string, count ...interface{}) string {
+	if len(count) > 0 {
+		n, err := toNumber(count[0])
+		if err == nil && n.float() == 1 {
+			return word
+		}
+	}
+
+	return inflect(word, func(w string) string {
+		if p, ok := irregulars[w]; ok {
+			return p
+		}
+		for _, p := range irregulars {
+			if p == w {
+				return w
+			}
+		}
+		return applyRules(pluralRules, w)
+	})
+}
+
+// Singularize returns the english singular of a word
+func Singularize(word string) string {
+	return inflect(word, func(w string) string {
+		for s, p := range irregulars {
+			if p == w {
+				return s
+			}
+		}
+		if _, ok := irregulars[w]; ok {
+			return w
+		}
+		return applyRules(singularRules, w)
+	})
+}
+
+// inflect applies f to the lower case word, ignoring uncountable words
+// and keeping upper case words or initial capitals
+func inflect(word string, f func(string) string) string {
+	lower := strings.ToLower(word)
+	if word == "" || uncountables[lower] {
+		return word
+	}
+
+	result := f(lower)
+	switch {
+	case len(word) > 1 && word == strings.ToUpper(word):
+		return strings.ToUpper(result)
+	case unicode.IsUpper([]rune(word)[0]):
+		r := []rune(result)
+		r[0] = unicode.ToUpper(r[0])
+		return string(r)
+	}
+	return result
+}
+
+// applyRules applies the first matching rule to word
+func applyRules(rules []inflection, word string) string {
+	for _, rule := range rules {
+		if rule.pattern.MatchString(word) {
+			return rule.pattern.ReplaceAllString(word, rule.replacement)
+		}
+	}
+	return word
+}
+
+// inflections compiles a list of patterns and replacements
+func inflections(rules [][2]string) []inflection {
+	var compiled []inflection
+	for _, r := range rules {
+		compiled = append(compiled, inflection{regexp.MustCompile(r[0]), r[1]})
+	}
+	return compiled
+}
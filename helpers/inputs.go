@@ -0,0 +1,248 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// INPUTS
+
+// These helpers render html5 inputs with the form partials, formatting values as the input type requires.
+// Attributes given as strings must not contain user input, use Attrs for attributes which may.
+
+// CheckboxValue is the value sent by checked checkboxes
+var CheckboxValue = "1"
+
+// UncheckedValue is the value sent by unchecked checkboxes, using a hidden field before the checkbox
+var UncheckedValue = "0"
+
+// Checkbox renders the forms/checkbox.html.got partial, with a hidden field so that a value is sent if it is not checked
+func Checkbox(label interface{}, name string, checked bool, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderCheckbox(label, name, checked, errors, args)
+}
+
+// renderCheckbox renders the forms/checkbox.html.got partial with validation errors for the field
+func renderCheckbox(label interface{}, name string, checked bool, errors []string, args []interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	// The partial sets the type
+	attributes.remove("type")
+	return Partial("forms/checkbox.html.got", fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"value":      CheckboxValue,
+		"unchecked":  UncheckedValue,
+		"checked":    checked,
+		"attributes": attributes.HTMLAttr(),
+	}))
+}
+
+// RadioGroup renders the forms/radiogroup.html.got partial, a group of radio buttons for the options given (see Select)
+func RadioGroup(label interface{}, name string, value interface{}, options interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderRadioGroup(label, name, value, options, errors, args)
+}
+
+// renderRadioGroup renders the forms/radiogroup.html.got partial with validation errors for the field
+func renderRadioGroup(label interface{}, name string, value interface{}, options interface{}, errors []string, args []interface{}) (got.HTML, error) {
+	attributes, err := joinAttributes(args)
+	if err != nil {
+		return "", err
+	}
+	// The partial sets the type
+	attributes.remove("type")
+	context, err := selectOptions(value, options, fieldContext(name, errors, map[string]interface{}{
+		"label":      label,
+		"attributes": attributes.HTMLAttr(),
+	}))
+	if err != nil {
+		return "", err
+	}
+	return Partial("forms/radiogroup.html.got", context)
+}
+
+// SelectMultiple renders the forms/select.html.got partial allowing multiple selections,
+// given a slice of selected values (e.g. []int64 or []string) and options, and optionally the render context (see Select)
+func SelectMultiple(label interface{}, name string, values interface{}, options interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderSelect(label, name, multipleValues(values), options, errors, args)
+}
+
+// multipleValues returns the values given as a slice, so that selects allow multiple selections
+func multipleValues(values interface{}) interface{} {
+	if _, multiple := selectedValues(values); multiple {
+		return values
+	}
+	if values == nil {
+		return []interface{}{}
+	}
+	return []interface{}{values}
+}
+
+// NumberField renders a number input, numeric args are the min, max and step, other args are attributes,
+// a nil limit is omitted, e.g. {{numberfield "Quantity" "quantity" .quantity 1 nil 1}}
+func NumberField(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return numberInput("number", label, name, v, errors, args)
+}
+
+// RangeField renders a range input, numeric args are the min, max and step, other args are attributes
+func RangeField(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return numberInput("range", label, name, v, errors, args)
+}
+
+// numberInput renders a number or range input with validation errors
+func numberInput(t string, label interface{}, name string, v interface{}, errors []string, args []interface{}) (got.HTML, error) {
+	var attributes []interface{}
+	limits := []string{"min", "max", "step"}
+	for _, a := range args {
+		switch s := a.(type) {
+		case string:
+			// Strings are limits if they are numbers or empty, and attributes otherwise
+			if _, err := strconv.ParseFloat(s, 64); err != nil && s != "" {
+				attributes = append(attributes, a)
+				continue
+			}
+		case got.HTMLAttr, *Attrs, Attrs:
+			attributes = append(attributes, a)
+			continue
+		}
+		if len(limits) == 0 {
+			return "", fmt.Errorf("helpers: too many limits for %s field %s", t, name)
+		}
+		n, err := formatNumber(a)
+		if err != nil {
+			return "", err
+		}
+		if n != "" {
+			attributes = append(attributes, fmt.Sprintf("%s=%q", limits[0], n))
+		}
+		limits = limits[1:]
+	}
+	// The type is added last so that it replaces any type given
+	attributes = append(attributes, fmt.Sprintf("type=%q", t))
+
+	value, err := formatNumber(v)
+	if err != nil {
+		return "", err
+	}
+	return renderField(label, name, value, errors, attributes)
+}
+
+// EmailField renders an email input
+func EmailField(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField(label, name, v, errors, append(args, `type="email"`))
+}
+
+// TelField renders a telephone number input
+func TelField(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField(label, name, v, errors, append(args, `type="tel"`))
+}
+
+// URLField renders a url input
+func URLField(label interface{}, name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField(label, name, v, errors, append(args, `type="url"`))
+}
+
+// DateTimeFieldFormat is the format of datetime fields
+var DateTimeFieldFormat = FieldFormat{Type: "datetime-local", Layout: "2006-01-02T15:04"}
+
+// TimeFieldFormat is the format of time fields
+var TimeFieldFormat = FieldFormat{Type: "time", Layout: "15:04"}
+
+// DateTimeField renders a datetime input formatted as DateTimeFieldFormat, zero times are rendered as blank.
+// The args may include a *time.Location or render context, to show the time in that time zone
+func DateTimeField(label interface{}, name string, t time.Time, args ...interface{}) (got.HTML, error) {
+	args, errors, loc := fieldArgs(name, args)
+	return renderTimeField(label, name, t, DateTimeFieldFormat, errors, append(args, loc))
+}
+
+// TimeField renders a time input formatted as TimeFieldFormat, zero times are rendered as blank.
+// The args may include a *time.Location or render context, to show the time in that time zone
+func TimeField(label interface{}, name string, t time.Time, args ...interface{}) (got.HTML, error) {
+	args, errors, loc := fieldArgs(name, args)
+	return renderTimeField(label, name, t, TimeFieldFormat, errors, append(args, loc))
+}
+
+// renderTimeField renders a time input in the format given with validation errors
+func renderTimeField(label interface{}, name string, t time.Time, format FieldFormat, errors []string, args []interface{}) (got.HTML, error) {
+	args, loc := zoneArgs(args)
+	return renderField(label, name, formatTime(t, format.Layout, loc), errors, append(args, fmt.Sprintf("type=%q", format.Type)))
+}
+
+// ParseDateTimeField parses a value sent by a datetime field, in the layout of DateTimeFieldFormat
+// (with or without seconds), in the time zone given as a *time.Location, zone name or render context, or UTC if none.
+// Blank values return a zero time.
+func ParseDateTimeField(s string, zone ...interface{}) (time.Time, error) {
+	return parseTime(s, zone, DateTimeFieldFormat.Layout, "2006-01-02T15:04:05")
+}
+
+// colorValue matches the values allowed for color inputs
+var colorValue = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ColorField renders a color input, values which are not of the form #rrggbb are rendered as #000000
+func ColorField(label interface{}, name string, v string, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField(label, name, formatColor(v), errors, append(args, `type="color"`))
+}
+
+// HiddenField renders a hidden input
+func HiddenField(name string, v interface{}, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	return renderField("", name, v, errors, append(args, `type="hidden"`))
+}
+
+// FileField renders a file input, accepting the comma separated file types or extensions given if any,
+// e.g. {{filefield "Image" "image" "image/png,image/jpeg"}}
+func FileField(label interface{}, name string, accept string, args ...interface{}) (got.HTML, error) {
+	args, errors, _ := fieldArgs(name, args)
+	if accept != "" {
+		args = append(args, &Attrs{list: []attr{{name: "accept", value: accept}}})
+	}
+	return renderField(label, name, "", errors, append(args, `type="file"`))
+}
+
+// formatNumber formats a number for use in number inputs, without grouping or exponents,
+// nil and empty strings are formatted as an empty string
+func formatNumber(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if s == "" {
+			return "", nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return "", fmt.Errorf("helpers: invalid number %s", s)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	n, err := toNumber(v)
+	if err != nil {
+		return "", err
+	}
+	if n.isFloat {
+		return strconv.FormatFloat(n.f, 'f', -1, 64), nil
+	}
+	return strconv.FormatInt(n.i, 10), nil
+}
+
+// formatColor returns the color in lower case if it is valid for color inputs, or #000000
+func formatColor(v string) string {
+	if !colorValue.MatchString(v) {
+		return "#000000"
+	}
+	return strings.ToLower(v)
+}
@@ -0,0 +1,162 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestInputs tests html5 inputs format values and attributes
+func TestInputs(t *testing.T) {
+	when := time.Date(2020, 3, 4, 9, 5, 0, 0, time.UTC)
+	r, err := Checkbox("Agree", "agree", true, "required")
+	assertHTML(t, "checkbox", r, err, `<input type="hidden" name="agree" value="0"><label><input type="checkbox" name="agree" value="1" checked required> Agree</label>`)
+	r, err = Checkbox("Agree", "agree", false)
+	assertHTML(t, "checkbox unchecked", r, err, `<input type="checkbox" name="agree" value="1" > Agree`)
+	r, err = RadioGroup("Size", "size", "m", StringOptions("s", "m"))
+	assertHTML(t, "radiogroup", r, err, `<legend>Size</legend>`, `<input type="radio" name="size" value="s" > s</label>`, `<input type="radio" name="size" value="m" checked > m</label>`)
+	r, err = SelectMultiple("Tags", "tags", []int64{1, 3}, []Option{NewOption(1, "a"), NewOption(2, "b"), NewOption(3, "c")})
+	assertHTML(t, "selectmultiple int64", r, err, `<select type="select" name="tags" id="tags" multiple>`, `<option value="1" selected>a</option>`, `<option value="2" >b</option>`, `<option value="3" selected>c</option>`)
+	r, err = SelectMultiple("Tags", "tags", []string{"b"}, StringOptions("a", "b"))
+	assertHTML(t, "selectmultiple string", r, err, `<option value="a" >a</option>`, `<option value="b" selected>b</option>`)
+	r, err = NumberField("Qty", "qty", 2.50, 0, 10, 0.5, "required")
+	assertHTML(t, "number", r, err, `<input name="qty" value="2.5" min="0" max="10" step="0.5" required type="number">`)
+	r, err = NumberField("Qty", "qty", 1, "0", "", "0.5", "required")
+	assertHTML(t, "number strings", r, err, `value="1" min="0" step="0.5" required type="number">`)
+	r, err = NumberField("Qty", "qty", 1, `type="text"`)
+	assertHTML(t, "number type", r, err, `value="1" type="number">`)
+	a, _ := NewAttrs("type", "text", "data-type", "x")
+	r, err = EmailField("Email", "email", "", a)
+	assertHTML(t, "email type", r, err, `value="" type="email" data-type="x">`)
+	r, err = Field("Name", "name", "", `data-type="x"`)
+	assertHTML(t, "data type", r, err, `value="" data-type="x" type="text">`)
+	r, err = Checkbox("Agree", "agree", false, `type="text"`)
+	assertHTML(t, "checkbox type", r, err, `<input type="checkbox" name="agree" value="1" > Agree`)
+	r, err = NumberField("Qty", "qty", int64(1000000))
+	assertHTML(t, "number int", r, err, `value="1000000" type="number"`)
+	r, err = RangeField("Vol", "vol", uint8(5), 1, 11)
+	assertHTML(t, "range", r, err, `value="5" min="1" max="11" type="range"`)
+	r, err = EmailField("Email", "email", "a@b.com")
+	assertHTML(t, "email", r, err, `value="a@b.com" type="email"`)
+	r, err = TelField("Tel", "tel", "+44 1234")
+	assertHTML(t, "tel", r, err, `value="&#43;44 1234" type="tel"`)
+	r, err = URLField("URL", "url", `https://a.com/?q="x"`)
+	assertHTML(t, "url", r, err, `value="https://a.com/?q=&#34;x&#34;" type="url"`)
+	r, err = DateTimeField("At", "at", when)
+	assertHTML(t, "datetime", r, err, `value="2020-03-04T09:05" type="datetime-local"`)
+	r, err = TimeField("At", "at", when)
+	assertHTML(t, "time", r, err, `value="09:05" type="time"`)
+	r, err = ColorField("Color", "color", "#FF0000")
+	assertHTML(t, "color", r, err, `value="#ff0000" type="color"`)
+	r, err = ColorField("Color", "color", `red" onclick="x`)
+	assertHTML(t, "color invalid", r, err, `value="#000000" type="color"`)
+	r, err = HiddenField("id", 3)
+	assertHTML(t, "hidden", r, err, `<input name="id" value="3" type="hidden">`)
+	r, err = FileField("Image", "image", `image/png,image/"jpeg"`)
+	assertHTML(t, "file", r, err, `accept="image/png,image/&#34;jpeg&#34;" type="file"`)
+
+	if _, err := NumberField("Qty", "qty", "x"); err == nil {
+		t.Errorf("number invalid value failed")
+	}
+	if _, err := NumberField("Qty", "qty", 1, 0, 1, 1, 1); err == nil {
+		t.Errorf("number too many limits failed")
+	}
+}
+
+// TestFormBuilderInputs tests html5 inputs bound to model fields
+func TestFormBuilderInputs(t *testing.T) {
+	model := map[string]interface{}{"Published": true, "Size": "m", "TagIDs": []int64{2}, "Price": 9.99, "Email": "a@b.com"}
+	f, err := Form(model, "/products")
+	if err != nil {
+		t.Fatalf("form failed err:%s", err)
+	}
+
+	r, err := f.Checkbox("Published")
+	assertHTML(t, "checkbox", r, err, `name="published" value="1" checked`)
+	r, err = f.RadioGroup("Size", StringOptions("s", "m"))
+	assertHTML(t, "radiogroup", r, err, `name="size" value="m" checked`)
+	r, err = f.SelectMultiple("TagIDs", NumberOptions(1, 2))
+	assertHTML(t, "selectmultiple", r, err, `<option value="2" selected>2</option>`)
+	r, err = f.Number("Price", 0, nil, 0.01)
+	assertHTML(t, "number", r, err, `value="9.99" min="0" step="0.01" type="number"`)
+	r, err = f.Email("Email")
+	assertHTML(t, "email", r, err, `name="email" value="a@b.com" type="email"`)
+
+	r, err = f.Number("Price", 0, 100, 0.01)
+	if err != nil || !strings.Contains(string(r), `name="price" value="9.99" min="0" max="100" step="0.01" type="number"`) {
+		t.Errorf("number failed got:%s %v", r, err)
+	}
+	if _, err = f.Checkbox("Size"); err == nil {
+		t.Errorf("checkbox non-bool failed")
+	}
+}
+
+// TestTimeFields tests date and time fields render blank zero times and round trip through parsing
+func TestTimeFields(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zones not available: %s", err)
+	}
+	context := map[string]interface{}{"time_zone": paris}
+	when := time.Date(2020, 3, 4, 23, 30, 0, 0, time.UTC)
+
+	r, err := DateField("Date", "date", time.Time{})
+	assertHTML(t, "date zero", r, err, `type="text" value="" data-date=""`)
+	r, err = DateField("Date", "date", when, context, `required`)
+	assertHTML(t, "date zone", r, err, `value="Mar 5, 2020" data-date="2020-03-05" required`)
+	r, err = DateTimeField("At", "at", time.Time{})
+	assertHTML(t, "datetime zero", r, err, `value="" type="datetime-local"`)
+	r, err = DateTimeField("At", "at", when, paris)
+	assertHTML(t, "datetime zone", r, err, `value="2020-03-05T00:30" type="datetime-local"`)
+	r, err = TimeField("At", "at", time.Time{})
+	assertHTML(t, "time zero", r, err, `value="" type="time"`)
+	r, err = TimeField("At", "at", when, context)
+	assertHTML(t, "time zone", r, err, `value="00:30" type="time"`)
+
+	// Values rendered in a zone parse to the same time in that zone
+	d, err := ParseDateField("Mar 5, 2020", context)
+	if err != nil || !d.Equal(time.Date(2020, 3, 5, 0, 0, 0, 0, paris)) {
+		t.Errorf("parse date failed got:%s %v", d, err)
+	}
+	d, err = ParseDateField("2020-03-05")
+	if err != nil || !d.Equal(time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parse date iso failed got:%s %v", d, err)
+	}
+	d, err = ParseDateTimeField("2020-03-05T00:30", "Europe/Paris")
+	if err != nil || !d.Equal(when) {
+		t.Errorf("parse datetime failed got:%s %v", d, err)
+	}
+	d, err = ParseDateTimeField("2020-03-05T00:30:15", paris)
+	if err != nil || !d.Equal(when.Add(15*time.Second)) {
+		t.Errorf("parse datetime seconds failed got:%s %v", d, err)
+	}
+	d, err = ParseDateTimeField(" ")
+	if err != nil || !d.IsZero() {
+		t.Errorf("parse blank failed got:%s %v", d, err)
+	}
+	if _, err = ParseDateField("March"); err == nil {
+		t.Errorf("parse invalid date failed")
+	}
+
+	// Formats may be changed to use other input types and layouts
+	defer func(f FieldFormat) { DateFieldFormat = f }(DateFieldFormat)
+	DateFieldFormat = FieldFormat{Type: "date", Layout: "2006-01-02"}
+	r, err = DateField("Date", "date", when)
+	if err != nil || !strings.Contains(string(r), `type="date" value="2020-03-04"`) {
+		t.Errorf("date format failed got:%s %v", r, err)
+	}
+
+	// Form builders show times in the render context time zone
+	f, err := Form(map[string]interface{}{"At": when}, "/events", context)
+	if err != nil {
+		t.Fatalf("form failed err:%s", err)
+	}
+	r, err = f.DateTime("At")
+	if err != nil || !strings.Contains(string(r), `name="at" value="2020-03-05T00:30"`) {
+		t.Errorf("form datetime failed got:%s %v", r, err)
+	}
+	r, err = f.Time("At")
+	if err != nil || !strings.Contains(string(r), `value="00:30" type="time"`) {
+		t.Errorf("form time failed got:%s %v", r, err)
+	}
+}
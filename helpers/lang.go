@@ -0,0 +1,140 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"math"
+	"strings"
+	"time"
+)
+
+// LOCALISED FORMATTING
+
+// LangDate returns a formatted date string given a lang, time and optional format,
+// with month and day names in the language given (as returned by LocaleFor).
+// It accepts the same optional zone or render context arguments as ZoneDate.
+// Date format layouts are for the date 2006-01-02
+func LangDate(lang string, t time.Time, args ...interface{}) got.HTML {
+	l := LocaleFor(lang)
+	layout, loc := timeArgs(args, l.DateFormat)
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return got.HTML(Escape(l.Format(t, layout)))
+}
+
+// LangTime returns a formatted time string given a lang, time and optional format,
+// with month and day names in the language given
+func LangTime(lang string, t time.Time, args ...interface{}) got.HTML {
+	l := LocaleFor(lang)
+	layout, loc := timeArgs(args, l.TimeFormat)
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return got.HTML(Escape(l.Format(t, layout)))
+}
+
+// LangNumberToCommas formats large numbers with the group separator of the language given
+// the entire number is still represented
+func LangNumberToCommas(lang string, n int64) string {
+	return formatInt(n, LocaleFor(lang).Group)
+}
+
+// LangNumber formats a number with the given number of decimal places,
+// using the decimal and group separators of the language given
+func LangNumber(lang string, f float64, places int) string {
+	l := LocaleFor(lang)
+	return formatFloat(f, places, l.Decimal, l.Group)
+}
+
+// LangNumberToHuman formats large numbers for human consumption in the language given,
+// some precision is lost, e.g. 1,2 k rather than 1234
+func LangNumberToHuman(lang string, n int64) string {
+	return compact(LocaleFor(lang), float64(n))
+}
+
+// Format returns the time formatted with layout, replacing month and day names
+// (January, Jan, Monday, Mon) with those of the locale
+func (l *Locale) Format(t time.Time, layout string) string {
+	names := []struct {
+		token string
+		name  string
+	}{
+		{"January", l.Months[t.Month()-1]},
+		{"Monday", l.Days[t.Weekday()]},
+		{"Jan", l.ShortMonths[t.Month()-1]},
+		{"Mon", l.ShortDays[t.Weekday()]},
+	}
+
+	// Split the layout at name tokens, formatting the parts between them as normal
+	output := ""
+	for len(layout) > 0 {
+		index, token, name := -1, "", ""
+		for _, n := range names {
+			i := strings.Index(layout, n.token)
+			if i >= 0 && (index < 0 || i < index) {
+				index, token, name = i, n.token, n.name
+			}
+		}
+
+		if index < 0 {
+			output += t.Format(layout)
+			break
+		}
+
+		output += t.Format(layout[:index]) + name
+		layout = layout[index+len(token):]
+	}
+
+	return output
+}
+
+// formatInt formats n with group between every 3 digits
+func formatInt(n int64, group string) string {
+	s := fmt.Sprintf("%d", n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	return sign + groupDigits(s, group)
+}
+
+// formatFloat formats f with places decimal places, using the decimal and group separators given
+func formatFloat(f float64, places int, decimal, group string) string {
+	// Round half away from zero first, as fmt rounds halves to even
+	scale := math.Pow(10, float64(places))
+	s := fmt.Sprintf("%.*f", places, math.Round(math.Abs(f)*scale)/scale)
+	fraction := ""
+	if i := strings.Index(s, "."); i >= 0 {
+		s, fraction = s[:i], decimal+s[i+1:]
+	}
+
+	sign := ""
+	if f < 0 && strings.Trim(s+fraction, "0"+decimal) != "" {
+		sign = "-"
+	}
+
+	return sign + groupDigits(s, group) + fraction
+}
+
+// groupDigits inserts group between every 3 digits of s, counting from the end
+func groupDigits(s, group string) string {
+	if len(s) < 4 {
+		return s
+	}
+
+	var formatted string
+	for i := len(s); i > 0; i -= 3 {
+		start := i - 3
+		if start < 0 {
+			start = 0
+		}
+		if formatted == "" {
+			formatted = s[start:i]
+		} else {
+			formatted = s[start:i] + group + formatted
+		}
+	}
+
+	return formatted
+}
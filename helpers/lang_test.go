@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLangDate tests localised dates and times
+func TestLangDate(t *testing.T) {
+	d := time.Date(2016, time.March, 7, 14, 5, 0, 0, time.UTC)
+
+	tests := [][2]string{
+		{string(LangDate("en", d)), "Mar 7, 2016"},
+		{string(LangDate("en", d)), string(Date(d))},
+		{string(LangTime("en", d)), string(Time(d))},
+		{string(LangDate("fr", d)), "7 mars 2016"},
+		{string(LangDate("fr-CA", d, "Monday 2 January")), "lundi 7 mars"},
+		{string(LangTime("de", d)), "7. März 2016 um 14:05"},
+		{string(LangDate("xx", d, "Mon Jan 2")), "Mon Mar 7"},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("lang date failed got:%s expected:%s", test[0], test[1])
+		}
+	}
+}
+
+// TestLangAgo tests localised relative times
+func TestLangAgo(t *testing.T) {
+	tests := [][2]string{
+		{LangAgo("en", time.Now().Add(-5*time.Hour)), "5 hours ago"},
+		{LangAgo("en", time.Now().Add(-1*time.Minute)), "1 minute ago"},
+		{LangAgo("fr", time.Now().Add(-5*time.Hour)), "il y a 5 heures"},
+		{LangAgo("de", time.Now().Add(-5*time.Minute), "short"), "5 Min."},
+		{LangAgo("de", time.Now().AddDate(0, 0, 3)), "in 3 Tagen"},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("lang ago failed got:%s expected:%s", test[0], test[1])
+		}
+	}
+}
+
+// TestLangNumber tests localised number formatting
+func TestLangNumber(t *testing.T) {
+	tests := [][2]string{
+		{LangNumberToCommas("en", 31300002), "31,300,002"},
+		{LangNumberToCommas("de", -1234567), "-1.234.567"},
+		{LangNumberToCommas("fr", 1234), "1\u202f234"},
+		{LangNumber("en", 1234.567, 2), "1,234.57"},
+		{LangNumber("de", -0.001, 2), "0,00"},
+		{LangNumber("fr", 1234.5, 1), "1\u202f234,5"},
+		{LangNumberToHuman("en", 999), "999"},
+		{LangNumberToHuman("en", 1500), "1.5k"},
+		{LangNumberToHuman("en", 2000000), "2M"},
+		{LangNumberToHuman("fr", 1200), "1,2\u00a0k"},
+		{LangNumberToHuman("de", 3400000000), "3,4 Mrd."},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("lang number failed got:%q expected:%q", test[0], test[1])
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package helpers
+
+import (
+	"strings"
+	"sync"
+)
+
+// Locale holds the data used to format dates, times and numbers for a language
+type Locale struct {
+	// Lang is the language code, e.g. fr
+	Lang string
+
+	// Month and day names, from January and Sunday (as in package time)
+	Months      [12]string
+	ShortMonths [12]string
+	Days        [7]string
+	ShortDays   [7]string
+
+	// Default layouts used by LangDate and LangTime
+	DateFormat string
+	TimeFormat string
+
+	// Number separators
+	Decimal string
+	Group   string
+
+	// Compact suffixes for thousands, millions and billions
+	Compact [3]string
+
+	// CurrencyAfter places currency symbols after amounts, e.g. 10,50 €
+	CurrencyAfter bool
+
+	// Relative time patterns for past and future durations, e.g. "%s ago" and "in %s"
+	Past   string
+	Future string
+
+	// Relative times which are not a number of units
+	JustNow   string
+	Today     string
+	Yesterday string
+	Tomorrow  string
+
+	// Units holds the singular and plural forms of time units, keyed by unit name
+	// (second, minute, hour, day, week, month, year), e.g. {"%d minute", "%d minutes"}
+	Units map[string][2]string
+
+	// ShortUnits holds abbreviated time units keyed by unit name, e.g. "%dm"
+	ShortUnits map[string]string
+
+	// One returns true if n takes the singular form
+	One func(n int64) bool
+}
+
+// unit returns the unit name formatted for n
+func (l *Locale) unit(name string, n int64) string {
+	forms := l.Units[name]
+	if l.One(n) {
+		return strings.Replace(forms[0], "%d", formatInt(n, l.Group), 1)
+	}
+	return strings.Replace(forms[1], "%d", formatInt(n, l.Group), 1)
+}
+
+// oneOnly is the plural rule for languages where only 1 is singular
+func oneOnly(n int64) bool {
+	return n == 1 || n == -1
+}
+
+// zeroOrOne is the plural rule for languages where 0 and 1 are singular
+func zeroOrOne(n int64) bool {
+	return n >= -1 && n <= 1
+}
+
+// locales holds the locales available, keyed by language
+var locales = map[string]*Locale{
+	"en": {
+		Lang:        "en",
+		Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DateFormat:  "Jan 2, 2006",
+		TimeFormat:  "Jan 2, 2006 at 15:04",
+		Decimal:     ".",
+		Group:       ",",
+		Compact:     [3]string{"k", "M", "B"},
+		Past:        "%s ago",
+		Future:      "in %s",
+		JustNow:     "just now",
+		Today:       "today",
+		Yesterday:   "yesterday",
+		Tomorrow:    "tomorrow",
+		Units: map[string][2]string{
+			"second": {"%d second", "%d seconds"},
+			"minute": {"%d minute", "%d minutes"},
+			"hour":   {"%d hour", "%d hours"},
+			"day":    {"%d day", "%d days"},
+			"week":   {"%d week", "%d weeks"},
+			"month":  {"%d month", "%d months"},
+			"year":   {"%d year", "%d years"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%ds",
+			"minute": "%dm",
+			"hour":   "%dh",
+			"day":    "%dd",
+			"week":   "%dw",
+			"month":  "%dmo",
+			"year":   "%dy",
+		},
+		One: oneOnly,
+	},
+	"fr": {
+		Lang:          "fr",
+		Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Days:          [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortDays:     [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		DateFormat:    "2 Jan 2006",
+		TimeFormat:    "2 Jan 2006 à 15:04",
+		Decimal:       ",",
+		Group:         "\u202f",
+		Compact:       [3]string{"\u00a0k", "\u00a0M", "\u00a0Md"},
+		CurrencyAfter: true,
+		Past:          "il y a %s",
+		Future:        "dans %s",
+		JustNow:       "à l'instant",
+		Today:         "aujourd'hui",
+		Yesterday:     "hier",
+		Tomorrow:      "demain",
+		Units: map[string][2]string{
+			"second": {"%d seconde", "%d secondes"},
+			"minute": {"%d minute", "%d minutes"},
+			"hour":   {"%d heure", "%d heures"},
+			"day":    {"%d jour", "%d jours"},
+			"week":   {"%d semaine", "%d semaines"},
+			"month":  {"%d mois", "%d mois"},
+			"year":   {"%d an", "%d ans"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d s",
+			"minute": "%d min",
+			"hour":   "%d h",
+			"day":    "%d j",
+			"week":   "%d sem.",
+			"month":  "%d mois",
+			"year":   "%d a",
+		},
+		One: zeroOrOne,
+	},
+	"de": {
+		Lang:          "de",
+		Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Days:          [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortDays:     [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		DateFormat:    "2. Jan 2006",
+		TimeFormat:    "2. Jan 2006 um 15:04",
+		Decimal:       ",",
+		Group:         ".",
+		Compact:       [3]string{" Tsd.", " Mio.", " Mrd."},
+		CurrencyAfter: true,
+		Past:          "vor %s",
+		Future:        "in %s",
+		JustNow:       "gerade eben",
+		Today:         "heute",
+		Yesterday:     "gestern",
+		Tomorrow:      "morgen",
+		Units: map[string][2]string{
+			"second": {"%d Sekunde", "%d Sekunden"},
+			"minute": {"%d Minute", "%d Minuten"},
+			"hour":   {"%d Stunde", "%d Stunden"},
+			"day":    {"%d Tag", "%d Tagen"},
+			"week":   {"%d Woche", "%d Wochen"},
+			"month":  {"%d Monat", "%d Monaten"},
+			"year":   {"%d Jahr", "%d Jahren"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d Sek.",
+			"minute": "%d Min.",
+			"hour":   "%d Std.",
+			"day":    "%d T.",
+			"week":   "%d W.",
+			"month":  "%d M.",
+			"year":   "%d J.",
+		},
+		One: oneOnly,
+	},
+	"es": {
+		Lang:          "es",
+		Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:   [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		Days:          [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortDays:     [7]string{"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."},
+		DateFormat:    "2 Jan 2006",
+		TimeFormat:    "2 Jan 2006, 15:04",
+		Decimal:       ",",
+		Group:         ".",
+		Compact:       [3]string{" mil", " M", " mil M"},
+		CurrencyAfter: true,
+		Past:          "hace %s",
+		Future:        "dentro de %s",
+		JustNow:       "ahora mismo",
+		Today:         "hoy",
+		Yesterday:     "ayer",
+		Tomorrow:      "mañana",
+		Units: map[string][2]string{
+			"second": {"%d segundo", "%d segundos"},
+			"minute": {"%d minuto", "%d minutos"},
+			"hour":   {"%d hora", "%d horas"},
+			"day":    {"%d día", "%d días"},
+			"week":   {"%d semana", "%d semanas"},
+			"month":  {"%d mes", "%d meses"},
+			"year":   {"%d año", "%d años"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d s",
+			"minute": "%d min",
+			"hour":   "%d h",
+			"day":    "%d d",
+			"week":   "%d sem.",
+			"month":  "%d m",
+			"year":   "%d a",
+		},
+		One: oneOnly,
+	},
+	"it": {
+		Lang:          "it",
+		Months:        [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Days:          [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortDays:     [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		DateFormat:    "2 Jan 2006",
+		TimeFormat:    "2 Jan 2006, 15:04",
+		Decimal:       ",",
+		Group:         ".",
+		Compact:       [3]string{"k", " Mln", " Mrd"},
+		CurrencyAfter: true,
+		Past:          "%s fa",
+		Future:        "tra %s",
+		JustNow:       "proprio ora",
+		Today:         "oggi",
+		Yesterday:     "ieri",
+		Tomorrow:      "domani",
+		Units: map[string][2]string{
+			"second": {"%d secondo", "%d secondi"},
+			"minute": {"%d minuto", "%d minuti"},
+			"hour":   {"%d ora", "%d ore"},
+			"day":    {"%d giorno", "%d giorni"},
+			"week":   {"%d settimana", "%d settimane"},
+			"month":  {"%d mese", "%d mesi"},
+			"year":   {"%d anno", "%d anni"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d s",
+			"minute": "%d min",
+			"hour":   "%d h",
+			"day":    "%d g",
+			"week":   "%d sett.",
+			"month":  "%d mesi",
+			"year":   "%d a",
+		},
+		One: oneOnly,
+	},
+	"pt": {
+		Lang:          "pt",
+		Months:        [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:   [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		Days:          [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		ShortDays:     [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		DateFormat:    "2 de Jan de 2006",
+		TimeFormat:    "2 de Jan de 2006, 15:04",
+		Decimal:       ",",
+		Group:         ".",
+		Compact:       [3]string{" mil", " mi", " bi"},
+		CurrencyAfter: true,
+		Past:          "há %s",
+		Future:        "em %s",
+		JustNow:       "agora mesmo",
+		Today:         "hoje",
+		Yesterday:     "ontem",
+		Tomorrow:      "amanhã",
+		Units: map[string][2]string{
+			"second": {"%d segundo", "%d segundos"},
+			"minute": {"%d minuto", "%d minutos"},
+			"hour":   {"%d hora", "%d horas"},
+			"day":    {"%d dia", "%d dias"},
+			"week":   {"%d semana", "%d semanas"},
+			"month":  {"%d mês", "%d meses"},
+			"year":   {"%d ano", "%d anos"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d s",
+			"minute": "%d min",
+			"hour":   "%d h",
+			"day":    "%d d",
+			"week":   "%d sem.",
+			"month":  "%d m",
+			"year":   "%d a",
+		},
+		One: zeroOrOne,
+	},
+	"nl": {
+		Lang:        "nl",
+		Months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		ShortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		Days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		ShortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		DateFormat:  "2 Jan 2006",
+		TimeFormat:  "2 Jan 2006 om 15:04",
+		Decimal:     ",",
+		Group:       ".",
+		Compact:     [3]string{"K", " mln.", " mld."},
+		Past:        "%s geleden",
+		Future:      "over %s",
+		JustNow:     "zojuist",
+		Today:       "vandaag",
+		Yesterday:   "gisteren",
+		Tomorrow:    "morgen",
+		Units: map[string][2]string{
+			"second": {"%d seconde", "%d seconden"},
+			"minute": {"%d minuut", "%d minuten"},
+			"hour":   {"%d uur", "%d uur"},
+			"day":    {"%d dag", "%d dagen"},
+			"week":   {"%d week", "%d weken"},
+			"month":  {"%d maand", "%d maanden"},
+			"year":   {"%d jaar", "%d jaar"},
+		},
+		ShortUnits: map[string]string{
+			"second": "%d s",
+			"minute": "%d min",
+			"hour":   "%d u",
+			"day":    "%d d",
+			"week":   "%d w",
+			"month":  "%d mnd",
+			"year":   "%d j",
+		},
+		One: oneOnly,
+	},
+}
+
+// localesMu guards locales
+var localesMu sync.RWMutex
+
+// DefaultLocale is the language used when no locale is found for a language
+var DefaultLocale = "en"
+
+// RegisterLocale adds or replaces the locale for l.Lang,
+// it should be called on startup before rendering templates
+func RegisterLocale(l *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	if l.One == nil {
+		l.One = oneOnly
+	}
+	locales[l.Lang] = l
+}
+
+// LocaleFor returns the locale for lang, falling back to the base language
+// (fr for fr-CA or fr_CA) and then to DefaultLocale
+func LocaleFor(lang string) *Locale {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	if l := locales[lang]; l != nil {
+		return l
+	}
+
+	base := strings.ToLower(lang)
+	if i := strings.IndexAny(base, "-_"); i > 0 {
+		base = base[:i]
+	}
+	if l := locales[base]; l != nil {
+		return l
+	}
+
+	return locales[DefaultLocale]
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"bytes"
+	got "html/template"
+
+	"github.com/yuin/goldmark"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// markdown converts CommonMark with GFM tables, task lists, strikethrough and autolinks to html.
+// Raw html in the source is omitted.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+	),
+	goldmark.WithRendererOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&taskCheckBoxRenderer{}, 100)),
+	),
+)
+
+// Markdown renders markdown as html, and then sanitizes it with the markdown policy (see MarkdownPolicy)
+func Markdown(s string) (got.HTML, error) {
+	h, err := RenderMarkdown([]byte(s))
+	if err != nil {
+		return "", err
+	}
+	return got.HTML(h), nil
+}
+
+// RenderMarkdown renders markdown as html, and then sanitizes it with the markdown policy
+func RenderMarkdown(src []byte) (string, error) {
+	var b bytes.Buffer
+	err := markdown.Convert(src, &b)
+	if err != nil {
+		return "", err
+	}
+	return SanitizeHTML("markdown", b.String())
+}
+
+// taskCheckBoxRenderer renders task list checkboxes with attribute values rather than empty boolean attributes
+type taskCheckBoxRenderer struct{}
+
+// RegisterFuncs registers the render func for task check boxes
+func (r *taskCheckBoxRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindTaskCheckBox, r.render)
+}
+
+// render writes a disabled checkbox for the task
+func (r *taskCheckBoxRenderer) render(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	if node.(*ast.TaskCheckBox).IsChecked {
+		w.WriteString(`<input type="checkbox" checked="checked" disabled="disabled"> `)
+	} else {
+		w.WriteString(`<input type="checkbox" disabled="disabled"> `)
+	}
+	return gast.WalkContinue, nil
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	got "html/template"
+	"testing"
+)
+
+// TestMarkdown tests markdown rendering and sanitization
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected got.HTML
+	}{
+		{"# Title", "<h1>Title</h1>\n"},
+		{"some *em* and **strong** and ~~del~~", "<p>some <em>em</em> and <strong>strong</strong> and <del>del</del></p>\n"},
+		{"| a | b |\n|---|:-:|\n| 1 | 2 |", "<table>\n<thead>\n<tr>\n<th>a</th>\n<th align=\"center\">b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td align=\"center\">2</td>\n</tr>\n</tbody>\n</table>\n"},
+		{"- [x] done\n- [ ] todo", "<ul>\n<li><input type=\"checkbox\" checked=\"checked\" disabled=\"disabled\"> done</li>\n<li><input type=\"checkbox\" disabled=\"disabled\"> todo</li>\n</ul>\n"},
+		{"see https://example.com", "<p>see <a href=\"https://example.com\">https://example.com</a></p>\n"},
+		{"<script>alert(1)</script>", "\n"},
+		{"[link](javascript:alert(1))", "<p><a>link</a></p>\n"},
+		{"<b onclick=\"x\">raw</b>", "<p>raw</p>\n"},
+	}
+	for _, test := range tests {
+		if r, err := Markdown(test.s); err != nil || r != test.expected {
+			t.Errorf("markdown %q failed got:%q %v want:%q", test.s, r, err, test.expected)
+		}
+	}
+}
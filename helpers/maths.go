@@ -2,59 +2,37 @@ package helpers
 
 import (
 	"fmt"
-	"strconv"
+	"math"
+	"reflect"
 	"strings"
 )
 
 // PRICES
 
-// FIXME - move to currency type with concrete implementations per currency, as it'd be neater than funcs with multiple options.  currency.GBP.PriceToCents something like that?
-
 // PriceToCentsString returns a price in cents as a string for use in params
-func PriceToCentsString(p string) string {
+func PriceToCentsString(p string) (string, error) {
 	if p == "" {
-		return "0" // Return 0 for blank price
+		return "0", nil // Return 0 for blank price
 	}
 
-	return fmt.Sprintf("%d", PriceToCents(p))
-}
-
-// PriceToCents converts a price string in human friendly notation (£45 or £34.40) to a price in pence as an int64
-func PriceToCents(p string) int {
-	price := strings.Replace(p, "£", "", -1)
-	price = strings.Replace(price, ",", "", -1) // assumed to be in thousands
-	price = strings.Replace(price, " ", "", -1)
-
-	var pennies int
-	var err error
-	if strings.Contains(price, ".") {
-		// Split the string on . and rejoin with padded pennies
-		parts := strings.Split(price, ".")
-
-		if len(parts[1]) == 0 {
-			parts[1] = "00"
-		} else if len(parts[1]) == 1 {
-			parts[1] = parts[1] + "0"
-		}
-
-		price = parts[0] + parts[1]
-
-		pennies, err = strconv.Atoi(price)
-	} else {
-		pennies, err = strconv.Atoi(price)
-		pennies = pennies * 100
-	}
+	cents, err := PriceToCents(p)
 	if err != nil {
-		fmt.Printf("Error converting price %s", price)
-		pennies = 0
+		return "", err
 	}
 
-	return pennies
+	return fmt.Sprintf("%d", cents), nil
+}
+
+// PriceToCents converts a price string in human friendly notation (£45 or £34.40) to a price in pence as an int
+// See Currency.Parse for other currencies
+func PriceToCents(p string) (int, error) {
+	cents, err := GBP.Parse(p)
+	return int(cents), err
 }
 
 // CentsToPrice converts a price in pence to a human friendly price including currency unit
-// At present it assumes the currency is pounds, it should instead take an optional param for currency
-// or not include it at all
+// It assumes the currency is pounds and does not group thousands (e.g. £1234.50),
+// see Money and Currency.Format for other currencies and grouping
 func CentsToPrice(p int64) string {
 	price := fmt.Sprintf("£%.2f", float64(p)/100.0)
 	return strings.TrimSuffix(price, ".00") // remove zero pence at end if we have it
@@ -73,17 +51,9 @@ func CentsToPriceShort(p int64) string {
 }
 
 // NumberToHuman formats large numbers for human consumption
-// some preceision is lost, e.g. 1.3m rather than 130000001
+// some precision is lost, e.g. 1.3M rather than 1300001, see Compact
 func NumberToHuman(n int64) string {
-	if n >= 100000000000 { // If greater than 1 billion use b suffix
-		return fmt.Sprintf("%.2fb", float64(n)/100000000000.0)
-	} else if n >= 100000000 { // If greater than 1m use m suffix
-		return fmt.Sprintf("%.2fm", float64(n)/100000000.0)
-	} else if n >= 1000 { // If greater than 1000 use k suffix
-		return fmt.Sprintf("%.2fk", float64(n)/1000.0)
-	}
-	// Just format as a string
-	return fmt.Sprintf("%d", n)
+	return Compact(n)
 }
 
 // NumberToCommas formats large numbers with commas
@@ -116,27 +86,260 @@ func CentsToBase(p int64) string {
 	return fmt.Sprintf("%.2f", float64(p)/100.0)
 }
 
-// Mod returns a modulo b
-func Mod(a int, b int) int {
-	return a % b
+// MATHS
+
+// These helpers accept any int, uint or float type, so that values from models
+// (int64, float64 etc) can be used in templates without conversion.
+// The result is an int64 if all arguments are integers, and a float64 otherwise.
+
+// number holds a numeric value as either an int64 or a float64
+type number struct {
+	i       int64
+	f       float64
+	isFloat bool
+}
+
+// float returns the value as a float64
+func (n number) float() float64 {
+	if n.isFloat {
+		return n.f
+	}
+	return float64(n.i)
+}
+
+// value returns the value as an int64 or float64
+func (n number) value() interface{} {
+	if n.isFloat {
+		return n.f
+	}
+	return n.i
+}
+
+// toNumber returns a number given any int, uint or float type
+func toNumber(v interface{}) (number, error) {
+	if v == nil {
+		return number{}, fmt.Errorf("helpers: nil is not a number")
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{i: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return number{f: float64(u), isFloat: true}, nil
+		}
+		return number{i: int64(u)}, nil
+	case reflect.Float32, reflect.Float64:
+		return number{f: rv.Float(), isFloat: true}, nil
+	}
+
+	return number{}, fmt.Errorf("helpers: %T is not a number", v)
+}
+
+// toNumbers returns numbers for a and b
+func toNumbers(a, b interface{}) (number, number, error) {
+	x, err := toNumber(a)
+	if err != nil {
+		return x, x, err
+	}
+	y, err := toNumber(b)
+	return x, y, err
 }
 
 // Add returns a + b
-func Add(a int, b int) int {
-	return a + b
+func Add(a, b interface{}) (interface{}, error) {
+	x, y, err := toNumbers(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x.isFloat || y.isFloat {
+		return x.float() + y.float(), nil
+	}
+	return x.i + y.i, nil
 }
 
 // Subtract returns a - b
-func Subtract(a int, b int) int {
-	return a - b
+func Subtract(a, b interface{}) (interface{}, error) {
+	x, y, err := toNumbers(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x.isFloat || y.isFloat {
+		return x.float() - y.float(), nil
+	}
+	return x.i - y.i, nil
+}
+
+// Multiply returns a * b
+func Multiply(a, b interface{}) (interface{}, error) {
+	x, y, err := toNumbers(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x.isFloat || y.isFloat {
+		return x.float() * y.float(), nil
+	}
+	return x.i * y.i, nil
+}
+
+// Divide returns a / b, integer division is used if both are integers
+// an error is returned if b is zero
+func Divide(a, b interface{}) (interface{}, error) {
+	x, y, err := toNumbers(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if y.float() == 0 {
+		return nil, fmt.Errorf("helpers: divide by zero")
+	}
+	if x.isFloat || y.isFloat {
+		return x.float() / y.float(), nil
+	}
+	return x.i / y.i, nil
+}
+
+// Mod returns a modulo b, an error is returned if b is zero
+func Mod(a, b interface{}) (interface{}, error) {
+	x, y, err := toNumbers(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if y.float() == 0 {
+		return nil, fmt.Errorf("helpers: modulo by zero")
+	}
+	if x.isFloat || y.isFloat {
+		return math.Mod(x.float(), y.float()), nil
+	}
+	return x.i % y.i, nil
+}
+
+// Min returns the smallest of the numbers given
+func Min(a interface{}, rest ...interface{}) (interface{}, error) {
+	return extreme(a, rest, func(x, y float64) bool { return x < y })
+}
+
+// Max returns the largest of the numbers given
+func Max(a interface{}, rest ...interface{}) (interface{}, error) {
+	return extreme(a, rest, func(x, y float64) bool { return x > y })
+}
+
+// extreme returns the number which is better than all others according to better
+func extreme(a interface{}, rest []interface{}, better func(x, y float64) bool) (interface{}, error) {
+	result, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range rest {
+		n, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		if better(n.float(), result.float()) {
+			result = n
+		}
+	}
+	return result.value(), nil
 }
 
-// Odd returns true if a is odd
-func Odd(a int) bool {
-	return a%2 == 0
+// Round returns a rounded to the nearest integer (halves away from zero) as an int64,
+// or to the given number of decimal places as a float64
+func Round(a interface{}, places ...int) (interface{}, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(places) > 0 {
+		scale := math.Pow(10, float64(places[0]))
+		return math.Round(n.float()*scale) / scale, nil
+	}
+	if !n.isFloat {
+		return n.i, nil
+	}
+	return int64(math.Round(n.f)), nil
 }
 
-// Int64 returns an int64 from an int
-func Int64(i int) int64 {
-	return int64(i)
+// Ceil returns the least integer greater than or equal to a as an int64
+func Ceil(a interface{}) (int64, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return 0, err
+	}
+	if !n.isFloat {
+		return n.i, nil
+	}
+	return int64(math.Ceil(n.f)), nil
+}
+
+// Floor returns the greatest integer less than or equal to a as an int64
+func Floor(a interface{}) (int64, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return 0, err
+	}
+	if !n.isFloat {
+		return n.i, nil
+	}
+	return int64(math.Floor(n.f)), nil
+}
+
+// Abs returns the absolute value of a
+func Abs(a interface{}) (interface{}, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	if n.isFloat {
+		return math.Abs(n.f), nil
+	}
+	if n.i < 0 {
+		return -n.i, nil
+	}
+	return n.i, nil
+}
+
+// Even returns true if a is an even integer
+func Even(a interface{}) (bool, error) {
+	n, err := toInteger(a)
+	if err != nil {
+		return false, err
+	}
+	return n%2 == 0, nil
+}
+
+// Odd returns true if a is an odd integer
+func Odd(a interface{}) (bool, error) {
+	n, err := toInteger(a)
+	if err != nil {
+		return false, err
+	}
+	return n%2 != 0, nil
+}
+
+// Int64 returns an int64 from any int, uint or float type (floats are truncated)
+func Int64(a interface{}) (int64, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return 0, err
+	}
+	if n.isFloat {
+		return int64(n.f), nil
+	}
+	return n.i, nil
+}
+
+// toInteger returns an int64 given any int or uint type, or a float with no fractional part
+func toInteger(a interface{}) (int64, error) {
+	n, err := toNumber(a)
+	if err != nil {
+		return 0, err
+	}
+	if n.isFloat {
+		if n.f != math.Trunc(n.f) {
+			return 0, fmt.Errorf("helpers: %v is not an integer", a)
+		}
+		return int64(n.f), nil
+	}
+	return n.i, nil
 }
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMaths tests numeric helpers with mixed types
+func TestMaths(t *testing.T) {
+	type fn func() (interface{}, error)
+
+	tests := []struct {
+		name     string
+		f        fn
+		expected interface{}
+	}{
+		{"add ints", func() (interface{}, error) { return Add(1, int64(2)) }, int64(3)},
+		{"add uint float", func() (interface{}, error) { return Add(uint8(1), 0.5) }, 1.5},
+		{"sub", func() (interface{}, error) { return Subtract(int32(1), 3) }, int64(-2)},
+		{"mul", func() (interface{}, error) { return Multiply(2.5, 2) }, 5.0},
+		{"div ints", func() (interface{}, error) { return Divide(7, 2) }, int64(3)},
+		{"div floats", func() (interface{}, error) { return Divide(7.0, 2) }, 3.5},
+		{"mod", func() (interface{}, error) { return Mod(int64(7), uint(3)) }, int64(1)},
+		{"mod float", func() (interface{}, error) { return Mod(7.5, 2) }, 1.5},
+		{"min", func() (interface{}, error) { return Min(3, 1.5, int64(2)) }, 1.5},
+		{"max", func() (interface{}, error) { return Max(3, 1.5, int64(7)) }, int64(7)},
+		{"round", func() (interface{}, error) { return Round(2.5) }, int64(3)},
+		{"round negative", func() (interface{}, error) { return Round(-2.5) }, int64(-3)},
+		{"round places", func() (interface{}, error) { return Round(2.345, 2) }, 2.35},
+		{"round int", func() (interface{}, error) { return Round(uint16(4)) }, int64(4)},
+		{"ceil", func() (interface{}, error) { return Ceil(1.2) }, int64(2)},
+		{"floor", func() (interface{}, error) { return Floor(-1.2) }, int64(-2)},
+		{"abs int", func() (interface{}, error) { return Abs(int8(-4)) }, int64(4)},
+		{"abs float", func() (interface{}, error) { return Abs(-4.5) }, 4.5},
+		{"even", func() (interface{}, error) { return Even(int64(4)) }, true},
+		{"even odd", func() (interface{}, error) { return Even(3) }, false},
+		{"odd", func() (interface{}, error) { return Odd(uint32(3)) }, true},
+		{"odd even", func() (interface{}, error) { return Odd(2) }, false},
+		{"odd negative", func() (interface{}, error) { return Odd(-3) }, true},
+		{"int64", func() (interface{}, error) { return Int64(float32(3.9)) }, int64(3)},
+	}
+
+	for _, test := range tests {
+		got, err := test.f()
+		if err != nil || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s failed got:%#v %v expected:%#v", test.name, got, err, test.expected)
+		}
+	}
+
+	errors := []struct {
+		name string
+		f    fn
+	}{
+		{"div zero", func() (interface{}, error) { return Divide(1, 0) }},
+		{"div zero float", func() (interface{}, error) { return Divide(1.5, 0.0) }},
+		{"mod zero", func() (interface{}, error) { return Mod(1, uint(0)) }},
+		{"add string", func() (interface{}, error) { return Add("1", 2) }},
+		{"max nil", func() (interface{}, error) { return Max(1, nil) }},
+		{"odd float", func() (interface{}, error) { return Odd(1.5) }},
+	}
+
+	for _, test := range errors {
+		_, err := test.f()
+		if err == nil {
+			t.Errorf("%s failed to report error", test.name)
+		}
+	}
+}
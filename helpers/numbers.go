@@ -0,0 +1,169 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NUMBERS
+
+// These helpers accept any int, uint or float type (or a numeric string) so that they can
+// be used directly in templates, and fall back to printing the value if it is not numeric
+
+// Compact formats numbers in compact notation with k, M and B suffixes for thousands,
+// millions and billions, e.g. 1.5k or 2M. Some precision is lost.
+func Compact(v interface{}) string {
+	f, err := toFloat(v)
+	if err != nil {
+		return fallback(v)
+	}
+	return compact(LocaleFor(DefaultLocale), f)
+}
+
+// Percent formats a ratio as a percentage with optional decimal places (default 0),
+// e.g. 0.125 is 13% or 12.5% with 1 decimal place
+func Percent(v interface{}, places ...int) string {
+	f, err := toFloat(v)
+	if err != nil {
+		return fallback(v)
+	}
+	p := 0
+	if len(places) > 0 {
+		p = places[0]
+	}
+	return Precision(f*100, p) + "%"
+}
+
+// FileSize formats a number of bytes using decimal units (1 KB = 1000 bytes), e.g. 1.5 MB
+func FileSize(v interface{}) string {
+	return fileSize(v, 1000, []string{"bytes", "KB", "MB", "GB", "TB", "PB"})
+}
+
+// FileSizeIEC formats a number of bytes using binary units (1 KiB = 1024 bytes), e.g. 1.5 MiB
+func FileSizeIEC(v interface{}) string {
+	return fileSize(v, 1024, []string{"bytes", "KiB", "MiB", "GiB", "TiB", "PiB"})
+}
+
+// Ordinal formats an integer as an english ordinal, e.g. 1st, 2nd, 3rd, 11th or 22nd
+func Ordinal(v interface{}) string {
+	f, err := toFloat(v)
+	if err != nil || f != math.Trunc(f) {
+		return fallback(v)
+	}
+
+	n := int64(f)
+	suffix := "th"
+	rem := n % 100
+	if rem < 0 {
+		rem = -rem
+	}
+	if rem < 11 || rem > 13 {
+		switch rem % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// Precision formats a number with a fixed number of decimal places and commas, e.g. 1,234.50
+func Precision(v interface{}, places int) string {
+	f, err := toFloat(v)
+	if err != nil {
+		return fallback(v)
+	}
+	l := LocaleFor(DefaultLocale)
+	return formatFloat(f, places, l.Decimal, l.Group)
+}
+
+// ParseNumber parses a number written with optional commas (e.g. 1,234.5),
+// returning an error if it is invalid
+func ParseNumber(s string) (float64, error) {
+	n := strings.Replace(strings.TrimSpace(s), ",", "", -1)
+	f, err := strconv.ParseFloat(n, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("helpers: invalid number %q", s)
+	}
+	return f, nil
+}
+
+// ParseInteger parses an integer written with optional commas (e.g. 1,234),
+// returning an error if it is invalid
+func ParseInteger(s string) (int64, error) {
+	n := strings.Replace(strings.TrimSpace(s), ",", "", -1)
+	i, err := strconv.ParseInt(n, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("helpers: invalid integer %q", s)
+	}
+	return i, nil
+}
+
+// compact formats f in compact notation using the locale given
+func compact(l *Locale, f float64) string {
+	scales := []float64{1, 1e3, 1e6, 1e9}
+
+	// Find the largest scale, promoting if rounding reaches the next one (999,999 is 1M not 1000k)
+	i := 0
+	for i < len(scales)-1 && math.Abs(f) >= scales[i+1] {
+		i++
+	}
+	if i > 0 && i < len(scales)-1 && math.Abs(math.Round(f/scales[i]*10)/10) >= 1000 {
+		i++
+	}
+
+	if i == 0 {
+		return formatFloat(math.Round(f), 0, l.Decimal, l.Group)
+	}
+
+	s := formatFloat(f/scales[i], 1, l.Decimal, l.Group)
+	s = strings.TrimSuffix(s, l.Decimal+"0")
+	return s + l.Compact[i-1]
+}
+
+// fileSize formats v bytes using the base and units given
+func fileSize(v interface{}, base float64, units []string) string {
+	f, err := toFloat(v)
+	if err != nil {
+		return fallback(v)
+	}
+
+	i := 0
+	for i < len(units)-1 && math.Abs(f) >= base {
+		f /= base
+		i++
+	}
+
+	if i == 0 {
+		if f == 1 {
+			return "1 byte"
+		}
+		return fmt.Sprintf("%d %s", int64(f), units[0])
+	}
+
+	s := strings.TrimSuffix(fmt.Sprintf("%.1f", f), ".0")
+	return s + " " + units[i]
+}
+
+// toFloat returns a float64 given any int, uint or float type, or a numeric string
+func toFloat(v interface{}) (float64, error) {
+	if s, ok := v.(string); ok {
+		return ParseNumber(s)
+	}
+	n, err := toNumber(v)
+	return n.float(), err
+}
+
+// fallback returns the value printed for display when a number cannot be formatted
+func fallback(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
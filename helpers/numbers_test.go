@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"testing"
+)
+
+// TestNumbers tests number formatting helpers
+func TestNumbers(t *testing.T) {
+	tests := [][2]string{
+		{NumberToHuman(999), "999"},
+		{NumberToHuman(1000), "1k"},
+		{NumberToHuman(1250), "1.3k"},
+		{NumberToHuman(999999), "1M"},
+		{NumberToHuman(1300001), "1.3M"},
+		{NumberToHuman(2500000000), "2.5B"},
+		{NumberToHuman(-45000), "-45k"},
+		{Compact(uint8(12)), "12"},
+		{Compact("1,500"), "1.5k"},
+		{Compact("abc"), "abc"},
+		{Compact(nil), ""},
+		{Percent(0.125), "13%"},
+		{Percent(0.125, 1), "12.5%"},
+		{Percent(2), "200%"},
+		{FileSize(1), "1 byte"},
+		{FileSize(999), "999 bytes"},
+		{FileSize(1500), "1.5 KB"},
+		{FileSize(int64(3000000)), "3 MB"},
+		{FileSizeIEC(1536), "1.5 KiB"},
+		{FileSizeIEC(1048576), "1 MiB"},
+		{Ordinal(1), "1st"},
+		{Ordinal(2), "2nd"},
+		{Ordinal(3), "3rd"},
+		{Ordinal(4), "4th"},
+		{Ordinal(11), "11th"},
+		{Ordinal(12), "12th"},
+		{Ordinal(13), "13th"},
+		{Ordinal(21), "21st"},
+		{Ordinal(102), "102nd"},
+		{Ordinal(111), "111th"},
+		{Ordinal(1.5), "1.5"},
+		{Precision(1234.5, 2), "1,234.50"},
+		{Precision(float32(0.5), 0), "1"},
+		{Precision(-1234, 1), "-1,234.0"},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("number failed got:%s expected:%s", test[0], test[1])
+		}
+	}
+}
+
+// TestParseNumber tests parsing numbers with errors
+func TestParseNumber(t *testing.T) {
+	f, err := ParseNumber("1,234.5")
+	if err != nil || f != 1234.5 {
+		t.Errorf("parse number failed got:%f %v", f, err)
+	}
+
+	i, err := ParseInteger(" -1,234 ")
+	if err != nil || i != -1234 {
+		t.Errorf("parse integer failed got:%d %v", i, err)
+	}
+
+	for _, s := range []string{"", "1.2.3", "abc", "NaN", "Inf"} {
+		_, err = ParseNumber(s)
+		if err == nil {
+			t.Errorf("parse number failed to report error for %q", s)
+		}
+	}
+
+	for _, s := range []string{"1.5", "12a", "99999999999999999999"} {
+		_, err = ParseInteger(s)
+		if err == nil {
+			t.Errorf("parse integer failed to report error for %q", s)
+		}
+	}
+}
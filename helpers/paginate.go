@@ -0,0 +1,297 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"net/url"
+	"strconv"
+)
+
+// PAGINATION
+
+// Paginator describes the current page of a list split into numbered pages, for rendering with Paginate, e.g.
+//
+//	p := helpers.NewPaginator(r.URL.String(), total, 20, page)
+//	q.Offset(p.Offset()).Limit(p.Limit())
+//	view.AddKey("pager", p)
+//	...
+//	{{paginate .pager}}
+type Paginator struct {
+	// Total is the total number of items in the list
+	Total int64
+
+	// PerPage is the number of items on each page
+	PerPage int64
+
+	// Page is the current page number, starting at 1
+	Page int64
+
+	// URL is the url of the list, the query is preserved in page links
+	URL string
+
+	// Param is the query parameter for the page number, page by default
+	Param string
+
+	// Window is the number of pages linked either side of the current page, others are elided, 2 if not set
+	Window int64
+}
+
+// PageLink is a link to a page passed to pagination partials, Ellipsis links stand for pages which are not shown
+type PageLink struct {
+	Number   int64
+	URL      string
+	Current  bool
+	Ellipsis bool
+}
+
+// NewPaginator returns a Paginator for the list at url given, with page clamped to the pages available
+func NewPaginator(u string, total, perPage, page int64) *Paginator {
+	p := &Paginator{Total: total, PerPage: perPage, URL: u, Param: "page", Window: 2}
+	if p.PerPage < 1 {
+		p.PerPage = 1
+	}
+	p.Page = page
+	if p.Page > p.Pages() {
+		p.Page = p.Pages()
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return p
+}
+
+// Pages returns the number of pages, which is at least 1
+func (p *Paginator) Pages() int64 {
+	if p.Total <= 0 || p.PerPage <= 0 {
+		return 1
+	}
+	return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+// Offset returns the offset of the first item on the current page, for use in queries
+func (p *Paginator) Offset() int64 {
+	if p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.PerPage
+}
+
+// Limit returns the number of items on each page, for use in queries
+func (p *Paginator) Limit() int64 {
+	return p.PerPage
+}
+
+// HasPrev returns true if there is a page before the current page
+func (p *Paginator) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext returns true if there is a page after the current page
+func (p *Paginator) HasNext() bool {
+	return p.Page < p.Pages()
+}
+
+// PrevURL returns the url of the previous page, or an empty string if there is none
+func (p *Paginator) PrevURL() string {
+	if !p.HasPrev() {
+		return ""
+	}
+	return p.PageURL(p.Page - 1)
+}
+
+// NextURL returns the url of the next page, or an empty string if there is none
+func (p *Paginator) NextURL() string {
+	if !p.HasNext() {
+		return ""
+	}
+	return p.PageURL(p.Page + 1)
+}
+
+// PageURL returns the url of page n, preserving the query of the list url,
+// the page parameter is omitted for the first page, which is ? if the url is otherwise empty
+func (p *Paginator) PageURL(n int64) string {
+	if n > 1 {
+		return queryURL(p.URL, p.param(), strconv.FormatInt(n, 10))
+	}
+	// An empty url would link to the current page, so use an empty query
+	u := queryURL(p.URL, p.param(), "")
+	if u == "" {
+		return "?"
+	}
+	return u
+}
+
+// Links returns links to the first and last pages and those within Window pages of the current page,
+// with an ellipsis link in place of pages which are not shown, a Window of 0 is treated as 2
+func (p *Paginator) Links() []PageLink {
+	pages := p.Pages()
+	window := p.Window
+	if window == 0 {
+		window = 2
+	}
+	start, end := p.Page-window, p.Page+window
+	// Show pages rather than an ellipsis which would stand for a single page
+	if start <= 3 {
+		start = 1
+	}
+	if end >= pages-2 {
+		end = pages
+	}
+
+	var links []PageLink
+	if start > 1 {
+		links = append(links, p.link(1), PageLink{Ellipsis: true})
+	}
+	for n := start; n <= end; n++ {
+		links = append(links, p.link(n))
+	}
+	if end < pages {
+		links = append(links, PageLink{Ellipsis: true}, p.link(pages))
+	}
+	return links
+}
+
+// link returns the link to page n
+func (p *Paginator) link(n int64) PageLink {
+	return PageLink{Number: n, URL: p.PageURL(n), Current: n == p.Page}
+}
+
+// param returns the query parameter for the page number
+func (p *Paginator) param() string {
+	if p.Param == "" {
+		return "page"
+	}
+	return p.Param
+}
+
+// CursorPaginator describes the current page of a list which is paged with cursors, such as an infinite list,
+// where the cursors identify the items before and after the current page
+type CursorPaginator struct {
+	// URL is the url of the list, the query is preserved in page links
+	URL string
+
+	// Param is the query parameter for the cursor, cursor by default
+	Param string
+
+	// Prev is the cursor for the previous page, or empty if there is none
+	Prev string
+
+	// Next is the cursor for the next page, or empty if there is none
+	Next string
+}
+
+// PrevURL returns the url of the previous page, or an empty string if there is none
+func (p *CursorPaginator) PrevURL() string {
+	if p.Prev == "" {
+		return ""
+	}
+	return queryURL(p.URL, p.param(), p.Prev)
+}
+
+// NextURL returns the url of the next page, or an empty string if there is none
+func (p *CursorPaginator) NextURL() string {
+	if p.Next == "" {
+		return ""
+	}
+	return queryURL(p.URL, p.param(), p.Next)
+}
+
+// param returns the query parameter for the cursor
+func (p *CursorPaginator) param() string {
+	if p.Param == "" {
+		return "cursor"
+	}
+	return p.Param
+}
+
+// PaginationLabels are the labels used by the pagination partials, which apps may translate
+type PaginationLabels struct {
+	// Pagination labels the pager
+	Pagination string
+
+	// Prev and Next label the links to the previous and next pages
+	Prev string
+	Next string
+
+	// Page labels the links to numbered pages, formatted with the page number, e.g. "Page %d"
+	Page string
+
+	// PrevText and NextText are the text of the links of cursor pagers
+	PrevText string
+	NextText string
+}
+
+// DefaultPaginationLabels are the labels used if none are given to Paginate
+var DefaultPaginationLabels = PaginationLabels{
+	Pagination: "Pagination",
+	Prev:       "Previous page",
+	Next:       "Next page",
+	Page:       "Page %d",
+	PrevText:   "Previous",
+	NextText:   "Next",
+}
+
+// Paginate renders the pagination/pages.html.got partial for a Paginator,
+// or the pagination/cursor.html.got partial for a CursorPaginator, e.g. {{paginate .pager}}.
+// The labels may be given to translate them, e.g. {{paginate .pager .pagerLabels}}
+func Paginate(pager interface{}, labels ...PaginationLabels) (got.HTML, error) {
+	l := DefaultPaginationLabels
+	if len(labels) > 0 {
+		l = labels[0]
+	}
+	switch p := pager.(type) {
+	case Paginator:
+		return paginatePages(&p, l)
+	case *Paginator:
+		return paginatePages(p, l)
+	case CursorPaginator:
+		return paginateCursor(&p, l)
+	case *CursorPaginator:
+		return paginateCursor(p, l)
+	}
+	return "", fmt.Errorf("helpers: invalid paginator %v", pager)
+}
+
+// paginatePages renders the pagination/pages.html.got partial with the labels given
+func paginatePages(p *Paginator, l PaginationLabels) (got.HTML, error) {
+	return Partial("pagination/pages.html.got", map[string]interface{}{
+		"pages":           p.Links(),
+		"count":           p.Pages(),
+		"current":         p.Page,
+		"prev":            p.PrevURL(),
+		"next":            p.NextURL(),
+		"paginationLabel": l.Pagination,
+		"prevLabel":       l.Prev,
+		"nextLabel":       l.Next,
+		"pageLabel":       l.Page,
+	})
+}
+
+// paginateCursor renders the pagination/cursor.html.got partial with the labels given
+func paginateCursor(p *CursorPaginator, l PaginationLabels) (got.HTML, error) {
+	return Partial("pagination/cursor.html.got", map[string]interface{}{
+		"prev":            p.PrevURL(),
+		"next":            p.NextURL(),
+		"paginationLabel": l.Pagination,
+		"prevLabel":       l.Prev,
+		"nextLabel":       l.Next,
+		"prevText":        l.PrevText,
+		"nextText":        l.NextText,
+	})
+}
+
+// queryURL returns the url with the query parameter set to value, or removed if value is empty
+func queryURL(u, param, value string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return u
+	}
+	q := parsed.Query()
+	if value == "" {
+		q.Del(param)
+	} else {
+		q.Set(param, value)
+	}
+	parsed.RawQuery = q.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,134 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestPaginatorLinks tests page links are windowed with ellipses
+func TestPaginatorLinks(t *testing.T) {
+	tests := []struct {
+		total    int64
+		page     int64
+		expected string
+	}{
+		{0, 1, "[1]"},
+		{100, 1, "[1] 2 3 … 10"},
+		{100, 4, "1 2 3 [4] 5 6 … 10"},
+		{100, 6, "1 … 4 5 [6] 7 8 9 10"},
+		{200, 10, "1 … 8 9 [10] 11 12 … 20"},
+		{100, 10, "1 … 8 9 [10]"},
+		{100, 99, "1 … 8 9 [10]"},
+		{100, -1, "[1] 2 3 … 10"},
+	}
+	for _, test := range tests {
+		p := NewPaginator("/pages", test.total, 10, test.page)
+		var links []string
+		for _, l := range p.Links() {
+			switch {
+			case l.Ellipsis:
+				links = append(links, "…")
+			case l.Current:
+				links = append(links, fmt.Sprintf("[%d]", l.Number))
+			default:
+				links = append(links, fmt.Sprintf("%d", l.Number))
+			}
+		}
+		if r := strings.Join(links, " "); r != test.expected {
+			t.Errorf("links %d page %d failed got:%s want:%s", test.total, test.page, r, test.expected)
+		}
+	}
+}
+
+// TestPaginator tests page urls preserve the query and offsets are calculated for the page
+func TestPaginator(t *testing.T) {
+	p := NewPaginator("/pages?q=go&page=3", 95, 10, 3)
+	if p.Pages() != 10 || p.Offset() != 20 || p.Limit() != 10 {
+		t.Errorf("paginator failed got pages:%d offset:%d limit:%d", p.Pages(), p.Offset(), p.Limit())
+	}
+	if p.PrevURL() != "/pages?page=2&q=go" || p.NextURL() != "/pages?page=4&q=go" || p.PageURL(1) != "/pages?q=go" {
+		t.Errorf("paginator urls failed got:%s %s %s", p.PrevURL(), p.NextURL(), p.PageURL(1))
+	}
+
+	p = NewPaginator("/pages", 15, 10, 2)
+	if p.HasNext() || p.NextURL() != "" || !p.HasPrev() {
+		t.Errorf("paginator last page failed got next:%s", p.NextURL())
+	}
+}
+
+// TestPaginatorLiteral tests paginators built without NewPaginator use the default param and window
+func TestPaginatorLiteral(t *testing.T) {
+	p := Paginator{Total: 100, PerPage: 10, Page: 5}
+	if p.PageURL(1) != "?" || p.PageURL(2) != "?page=2" || p.PrevURL() != "?page=4" {
+		t.Errorf("paginator literal urls failed got:%s %s %s", p.PageURL(1), p.PageURL(2), p.PrevURL())
+	}
+	var numbers []int64
+	for _, l := range p.Links() {
+		numbers = append(numbers, l.Number)
+	}
+	if fmt.Sprint(numbers) != "[1 2 3 4 5 6 7 0 10]" {
+		t.Errorf("paginator literal links failed got:%v", numbers)
+	}
+	r, err := Paginate(p)
+	if err != nil || !strings.Contains(string(r), `<li><a href="?" aria-label="Page 1">1</a></li>`) {
+		t.Errorf("paginate literal failed got:%s %v", r, err)
+	}
+
+	p = Paginator{URL: "?page=3", Total: 100, PerPage: 10, Page: 3}
+	if p.PageURL(1) != "?" {
+		t.Errorf("paginator query url failed got:%s", p.PageURL(1))
+	}
+}
+
+// TestPaginate tests pagers rendered with the default partials
+func TestPaginate(t *testing.T) {
+	p := NewPaginator(`/pages?q="x"`, 100, 10, 5)
+	r, err := Paginate(p)
+	if err != nil {
+		t.Fatalf("paginate failed err:%s", err)
+	}
+	expected := []string{
+		`<nav class="pagination" aria-label="Pagination">`,
+		`<a href="/pages?page=4&amp;q=%22x%22" rel="prev" aria-label="Previous page">`,
+		`<a href="/pages?page=6&amp;q=%22x%22" rel="next" aria-label="Next page">`,
+		`<li class="current"><a href="/pages?page=5&amp;q=%22x%22" aria-current="page" aria-label="Page 5">5</a></li>`,
+		`<li class="ellipsis" aria-hidden="true">&hellip;</li>`,
+		`<li><a href="/pages?q=%22x%22" aria-label="Page 1">1</a></li>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(r), e) {
+			t.Errorf("paginate failed got:%s want:%s", r, e)
+		}
+	}
+
+	// A single page has no pager
+	r, err = Paginate(NewPaginator("/pages", 5, 10, 1))
+	if err != nil || r != "" {
+		t.Errorf("paginate single page failed got:%s %v", r, err)
+	}
+
+	r, err = Paginate(&CursorPaginator{URL: "/feed?tag=go", Next: "abc=="})
+	if err != nil || !strings.Contains(string(r), `<a href="/feed?cursor=abc%3D%3D&amp;tag=go" rel="next" aria-label="Next page">Next</a>`) || strings.Contains(string(r), `rel="prev"`) {
+		t.Errorf("paginate cursor failed got:%s %v", r, err)
+	}
+	r, err = Paginate(&CursorPaginator{URL: "/feed"})
+	if err != nil || r != "" {
+		t.Errorf("paginate cursor empty failed got:%s %v", r, err)
+	}
+
+	// Values are accepted as well as pointers, and labels may be translated
+	labels := PaginationLabels{Pagination: "Pagination", Prev: "Page précédente", Next: "Page suivante", Page: "Page %d", PrevText: "Précédent", NextText: "Suivant"}
+	r, err = Paginate(*p, labels)
+	if err != nil || !strings.Contains(string(r), `rel="prev" aria-label="Page précédente">`) || !strings.Contains(string(r), `aria-label="Page 5">5</a>`) {
+		t.Errorf("paginate value failed got:%s %v", r, err)
+	}
+	r, err = Paginate(CursorPaginator{URL: "/feed", Prev: "a"}, labels)
+	if err != nil || !strings.Contains(string(r), `rel="prev" aria-label="Page précédente">Précédent</a>`) {
+		t.Errorf("paginate cursor value failed got:%s %v", r, err)
+	}
+
+	if _, err = Paginate(p.Links()); err == nil {
+		t.Errorf("paginate invalid failed")
+	}
+}
@@ -0,0 +1,146 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	got "html/template"
+)
+
+// PARTIALS
+
+// PartialRenderer renders the partial template at path from the app's templates,
+// returning false if the app has no such template. It is set by the view package,
+// so that apps can override the partials used by helpers, e.g. forms/field.html.got
+var PartialRenderer func(path string, context map[string]interface{}) (got.HTML, bool, error)
+
+// defaultPartials holds the built-in partials used if the app does not define them,
+// they define the default theme for helpers which render partials.
+//
+// Form partials receive these keys in their context:
+// label, name, id, value, attributes (trusted got.HTMLAttr), options (for selects, with Value, Name, Selected and Disabled),
+// groups (the options in groups, with Label and Options, options which are not grouped are in a group with no label),
+// errors (validation messages for the field) and errorID (the id of the element containing messages).
+// Checkboxes also receive checked and unchecked (the value sent if not checked), and selects receive multiple.
+// The error summary partial receives errors, with Field, ID and Message.
+// Date fields also receive type and date, the value formatted as 2006-01-02, dates are blank if zero.
+// The form partial receives action, method, override and overrideField, token and tokenField, and attributes.
+//
+// The pagination/pages.html.got partial receives pages (PageLink), count (the number of pages), current,
+// and the prev and next urls, which are empty if there is no such page.
+// The pagination/cursor.html.got partial receives the prev and next urls.
+var defaultPartials = got.Must(got.New("").Parse(`
+{{- define "forms/form.html.got" -}}
+<form action="{{.action}}" method="{{.method}}" accept-charset="UTF-8" {{.attributes}}>
+{{- if .override}}<input type="hidden" name="{{.overrideField}}" value="{{.override}}">{{end}}
+{{- if .token}}<input type="hidden" name="{{.tokenField}}" value="{{.token}}">{{end}}
+{{- end -}}
+
+{{- define "forms/errors.html.got" -}}
+{{if .errors}}<span class="field-error-message" id="{{.errorID}}">{{range $i, $e := .errors}}{{if $i}} {{end}}{{$e}}{{end}}</span>{{end}}
+{{- end -}}
+
+{{- define "forms/field.html.got" -}}
+{{if .label}}<div class="field{{if .errors}} field-error{{end}}">
+         <label>{{.label}}</label>
+         <input name="{{.name}}" value="{{.value}}" {{.attributes}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>{{template "forms/errors.html.got" .}}
+         </div>{{else}}<input name="{{.name}}" value="{{.value}}" {{.attributes}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>{{template "forms/errors.html.got" .}}{{end}}
+{{- end -}}
+
+{{- define "forms/datefield.html.got" -}}
+<div class="field{{if .errors}} field-error{{end}}">
+         <label>{{.label}}</label>
+         <input name="{{.name}}" id="{{.id}}" class="date_field" type="{{.type}}" value="{{.value}}" data-date="{{.date}}" {{.attributes}} autocomplete="off"{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>{{template "forms/errors.html.got" .}}
+         </div>
+{{- end -}}
+
+{{- define "forms/textarea.html.got" -}}
+<div class="field{{if .errors}} field-error{{end}}">
+       <label>{{.label}}</label>
+       <textarea name="{{.name}}" {{.attributes}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>{{.value}}</textarea>{{template "forms/errors.html.got" .}}
+       </div>
+{{- end -}}
+
+{{- define "forms/checkbox.html.got" -}}
+<div class="field{{if .errors}} field-error{{end}}">
+         <input type="hidden" name="{{.name}}" value="{{.unchecked}}"><label><input type="checkbox" name="{{.name}}" value="{{.value}}"{{if .checked}} checked{{end}} {{.attributes}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}> {{.label}}</label>{{template "forms/errors.html.got" .}}
+         </div>
+{{- end -}}
+
+{{- define "forms/radiogroup.html.got" -}}
+<fieldset class="field{{if .errors}} field-error{{end}}"{{if .errors}} aria-describedby="{{.errorID}}"{{end}}>
+      <legend>{{.label}}</legend>
+      {{range .options}}<label><input type="radio" name="{{$.name}}" value="{{.Value}}"{{if .Selected}} checked{{end}}{{if .Disabled}} disabled{{end}} {{$.attributes}}{{if $.errors}} aria-invalid="true"{{end}}> {{.Name}}</label>
+      {{end}}{{template "forms/errors.html.got" .}}
+      </fieldset>
+{{- end -}}
+
+{{- define "forms/errorsummary.html.got" -}}
+{{if .errors}}<div class="error-summary" role="alert">
+      <ul>
+      {{range .errors}}<li><a href="#{{.ID}}">{{.Message}}</a></li>
+      {{end}}</ul>
+      </div>{{end}}
+{{- end -}}
+
+{{- define "forms/options.html.got" -}}
+{{range .groups}}{{if .Label}}<optgroup label="{{.Label}}">
+{{end}}{{range .Options}}<option value="{{.Value}}" {{if .Selected}}selected{{end}}{{if .Disabled}} disabled{{end}}{{with $.optionAttributes}} {{.}}{{end}}>{{.Name}}</option>
+{{end}}{{if .Label}}</optgroup>
+{{end}}{{end}}
+{{- end -}}
+
+{{- define "forms/select.html.got" -}}
+{{if .label}}<div class="field{{if .errors}} field-error{{end}}">
+      <label>{{.label}}</label>
+      <select type="select" name="{{.name}}"{{if .id}} id="{{.id}}"{{end}}{{if .multiple}} multiple{{end}}{{with .attributes}} {{.}}{{end}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>
+      {{template "forms/options.html.got" .}}
+      </select>{{template "forms/errors.html.got" .}}
+      </div>{{else}}<select type="select" name="{{.name}}"{{if .id}} id="{{.id}}"{{end}}{{if .multiple}} multiple{{end}}{{with .attributes}} {{.}}{{end}}{{if .errors}} aria-invalid="true" aria-describedby="{{.errorID}}"{{end}}>
+{{template "forms/options.html.got" .}}
+</select>{{template "forms/errors.html.got" .}}{{end}}
+{{- end -}}
+
+{{- define "pagination/pages.html.got" -}}
+{{if gt .count 1}}<nav class="pagination" aria-label="{{.paginationLabel}}">
+      <ul>
+      {{if .prev}}<li class="prev"><a href="{{.prev}}" rel="prev" aria-label="{{.prevLabel}}">&lsaquo;</a></li>
+      {{end}}{{range .pages}}{{if .Ellipsis}}<li class="ellipsis" aria-hidden="true">&hellip;</li>
+      {{else if .Current}}<li class="current"><a href="{{.URL}}" aria-current="page" aria-label="{{printf $.pageLabel .Number}}">{{.Number}}</a></li>
+      {{else}}<li><a href="{{.URL}}" aria-label="{{printf $.pageLabel .Number}}">{{.Number}}</a></li>
+      {{end}}{{end}}{{if .next}}<li class="next"><a href="{{.next}}" rel="next" aria-label="{{.nextLabel}}">&rsaquo;</a></li>
+      {{end}}</ul>
+      </nav>{{end}}
+{{- end -}}
+
+{{- define "pagination/cursor.html.got" -}}
+{{if or .prev .next}}<nav class="pagination" aria-label="{{.paginationLabel}}">
+      <ul>
+      {{if .prev}}<li class="prev"><a href="{{.prev}}" rel="prev" aria-label="{{.prevLabel}}">{{.prevText}}</a></li>
+      {{end}}{{if .next}}<li class="next"><a href="{{.next}}" rel="next" aria-label="{{.nextLabel}}">{{.nextText}}</a></li>
+      {{end}}</ul>
+      </nav>{{end}}
+{{- end -}}
+`))
+
+// Partial renders the partial template at path with the context given,
+// using the app's template if it has one, or the built-in default if not
+func Partial(path string, context map[string]interface{}) (got.HTML, error) {
+	if PartialRenderer != nil {
+		h, ok, err := PartialRenderer(path, context)
+		if ok || err != nil {
+			return h, err
+		}
+	}
+
+	t := defaultPartials.Lookup(path)
+	if t == nil {
+		return "", fmt.Errorf("helpers: no partial found for %s", path)
+	}
+
+	var b bytes.Buffer
+	err := t.Execute(&b, context)
+	if err != nil {
+		return "", err
+	}
+	return got.HTML(b.String()), nil
+}
@@ -0,0 +1,245 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// SANITIZATION POLICIES
+
+// Policy defines the html allowed by Sanitize for one kind of content, e.g. comments or articles
+type Policy struct {
+	// Tags is the list of tags allowed, other tags are removed but their text content is kept
+	Tags []string
+
+	// Attributes is the list of attributes allowed on allowed tags, event handler attributes are never allowed
+	Attributes []string
+
+	// Schemes is the list of url schemes allowed in href and src attributes, relative urls are always allowed
+	Schemes []string
+
+	// NoFollow adds rel="nofollow" to links
+	NoFollow bool
+
+	// TargetBlank adds target="_blank" and rel="noopener" to links
+	TargetBlank bool
+}
+
+// Policies available by default, others may be added with RegisterPolicy
+var (
+	// DefaultPolicy allows the tags allowed by the sanitize package, and is used if no policy is named
+	DefaultPolicy = &Policy{
+		Tags: []string{"h1", "h2", "h3", "h4", "h5", "h6", "div", "span", "hr", "p", "br", "b", "i", "strong", "em",
+			"ol", "ul", "li", "a", "img", "pre", "code", "blockquote", "article", "section"},
+		Attributes: []string{"id", "class", "src", "href", "title", "alt", "name", "rel"},
+		Schemes:    []string{"http", "https", "mailto"},
+	}
+
+	// StrictPolicy allows basic formatting and links only, which are marked nofollow
+	StrictPolicy = &Policy{
+		Tags:       []string{"p", "br", "b", "i", "strong", "em", "a", "code", "blockquote"},
+		Attributes: []string{"href", "title"},
+		Schemes:    []string{"http", "https", "mailto"},
+		NoFollow:   true,
+	}
+
+	// MarkdownPolicy allows the html produced by the Markdown helper
+	MarkdownPolicy = &Policy{
+		Tags: []string{"h1", "h2", "h3", "h4", "h5", "h6", "div", "span", "hr", "p", "br", "b", "i", "strong", "em", "del",
+			"ol", "ul", "li", "a", "img", "pre", "code", "blockquote", "table", "thead", "tbody", "tr", "th", "td", "input"},
+		Attributes: []string{"id", "class", "src", "href", "title", "alt", "name", "rel", "align", "type", "checked", "disabled"},
+		Schemes:    []string{"http", "https", "mailto"},
+	}
+)
+
+// policies holds the policies available, keyed by name
+var policies = map[string]*Policy{
+	"default":  DefaultPolicy,
+	"strict":   StrictPolicy,
+	"markdown": MarkdownPolicy,
+}
+
+// policiesMu guards policies
+var policiesMu sync.RWMutex
+
+// RegisterPolicy adds or replaces the named policy,
+// it should be called on startup before rendering templates
+func RegisterPolicy(name string, p *Policy) {
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+	policies[name] = p
+}
+
+// PolicyFor returns the named policy, or an error if it is not registered
+func PolicyFor(name string) (*Policy, error) {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+	p := policies[name]
+	if p == nil {
+		return nil, fmt.Errorf("helpers: no sanitize policy %s", name)
+	}
+	return p, nil
+}
+
+// SanitizeHTML sanitizes the html using the named policy
+func SanitizeHTML(name, s string) (string, error) {
+	p, err := PolicyFor(name)
+	if err != nil {
+		return "", err
+	}
+	return p.Sanitize(s)
+}
+
+// droppedTags are removed along with their content, unless allowed by the policy
+var droppedTags = map[string]bool{"title": true, "script": true, "style": true, "iframe": true, "frame": true,
+	"frameset": true, "noframes": true, "noembed": true, "embed": true, "applet": true, "object": true, "base": true}
+
+// neverAllowed are removed along with their content, even if allowed by the policy
+var neverAllowed = map[string]bool{"script": true, "style": true}
+
+// urlAttributes are attributes which contain urls, and are checked against Schemes
+var urlAttributes = map[string]bool{"href": true, "src": true, "cite": true, "action": true, "poster": true}
+
+// Sanitize returns the html with tags and attributes not allowed by the policy removed,
+// and with tags balanced, so that unclosed tags do not affect the page around the content
+func (p *Policy) Sanitize(s string) (string, error) {
+	var b bytes.Buffer
+	var open []string
+	dropping := ""
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() == io.EOF {
+				break
+			}
+			return "", z.Err()
+		}
+
+		token := z.Token()
+		if dropping != "" {
+			if tt == html.EndTagToken && token.Data == dropping {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if !p.allowed(token.Data) {
+				if droppedTags[token.Data] || neverAllowed[token.Data] {
+					if tt == html.StartTagToken && !voidElements[token.Data] {
+						dropping = token.Data
+					}
+				}
+				continue
+			}
+			token.Attr = p.cleanAttributes(token)
+			b.WriteString(token.String())
+			if tt == html.StartTagToken && !voidElements[token.Data] {
+				open = append(open, token.Data)
+			}
+		case html.EndTagToken:
+			// Close the most recent matching tag, and any left open within it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						fmt.Fprintf(&b, "</%s>", open[j])
+					}
+					open = open[:i]
+					break
+				}
+			}
+		case html.TextToken:
+			b.WriteString(token.String())
+		}
+		// Comments and doctypes are removed
+	}
+
+	// Close any tags left open
+	for i := len(open) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "</%s>", open[i])
+	}
+
+	return b.String(), nil
+}
+
+// allowed returns true if the policy allows this tag
+func (p *Policy) allowed(tag string) bool {
+	return !neverAllowed[tag] && includes(p.Tags, tag)
+}
+
+// cleanAttributes returns the attributes of token allowed by the policy,
+// adding rel and target attributes to links if required
+func (p *Policy) cleanAttributes(token html.Token) []html.Attribute {
+	var attrs []html.Attribute
+	var rel []string
+	link := false
+	for _, a := range token.Attr {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" || strings.HasPrefix(key, "on") || !includes(p.Attributes, key) {
+			continue
+		}
+		if urlAttributes[key] {
+			if !p.allowedURL(a.Val) {
+				continue
+			}
+			link = link || (token.Data == "a" && key == "href")
+		}
+		switch key {
+		case "rel":
+			rel = append(rel, strings.Fields(a.Val)...)
+			continue
+		case "target":
+			if p.TargetBlank {
+				continue
+			}
+		}
+		attrs = append(attrs, html.Attribute{Key: key, Val: a.Val})
+	}
+
+	if link && p.NoFollow && !includes(rel, "nofollow") {
+		rel = append(rel, "nofollow")
+	}
+	if link && p.TargetBlank {
+		attrs = append(attrs, html.Attribute{Key: "target", Val: "_blank"})
+		if !includes(rel, "noopener") {
+			rel = append(rel, "noopener")
+		}
+	}
+	if len(rel) > 0 {
+		attrs = append(attrs, html.Attribute{Key: "rel", Val: strings.Join(rel, " ")})
+	}
+
+	return attrs
+}
+
+// allowedURL returns true if the url is relative or has a scheme allowed by the policy
+func (p *Policy) allowedURL(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || includes(p.Schemes, strings.ToLower(u.Scheme))
+}
+
+// includes returns true if the list includes s
+func includes(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	got "html/template"
+	"testing"
+)
+
+// TestSanitize tests sanitizing with the default policy
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected got.HTML
+	}{
+		{"<p>hello <b>world</b></p>", "<p>hello <b>world</b></p>"},
+		{"<p onclick=\"alert(1)\">x</p>", "<p>x</p>"},
+		{"<script>alert(1)</script>text", "text"},
+		{"<iframe src=\"https://example.com\">inner</iframe>text", "text"},
+		{"<a href=\"javascript:alert(1)\">x</a>", "<a>x</a>"},
+		{"<a href=\"java\tscript:alert(1)\">x</a>", "<a>x</a>"},
+		{"<a href=\"&#106;avascript:alert(1)\">x</a>", "<a>x</a>"},
+		{"<a href=\"/page?a=1&amp;b=2\">x</a>", "<a href=\"/page?a=1&amp;b=2\">x</a>"},
+		{"<img src=\"data:image/png;base64,xx\" alt=\"a\">", "<img alt=\"a\">"},
+		{"<p>unclosed <em>tags", "<p>unclosed <em>tags</em></p>"},
+		{"</div>stray<!-- comment -->", "stray"},
+		{"<unknown>text</unknown> &lt;b&gt;", "text &lt;b&gt;"},
+	}
+	for _, test := range tests {
+		r, err := Sanitize(test.s)
+		if err != nil || r != test.expected {
+			t.Errorf("sanitize %q failed got:%q %v want:%q", test.s, r, err, test.expected)
+		}
+	}
+}
+
+// TestSanitizePolicies tests named policies with links and embeds
+func TestSanitizePolicies(t *testing.T) {
+	RegisterPolicy("comment", &Policy{
+		Tags:        []string{"p", "a", "iframe"},
+		Attributes:  []string{"href", "src", "rel", "target"},
+		Schemes:     []string{"https"},
+		NoFollow:    true,
+		TargetBlank: true,
+	})
+
+	tests := []struct {
+		policy   string
+		s        string
+		expected got.HTML
+	}{
+		{"comment", "<a href=\"https://example.com\" target=\"_self\" rel=\"external\">x</a>", "<a href=\"https://example.com\" target=\"_blank\" rel=\"external nofollow noopener\">x</a>"},
+		{"comment", "<a href=\"http://example.com\">x</a>", "<a>x</a>"},
+		{"comment", "<iframe src=\"https://example.com/embed\"></iframe>", "<iframe src=\"https://example.com/embed\"></iframe>"},
+		{"comment", "<script>alert(1)</script><b>bold</b>", "bold"},
+		{"strict", "<h1><a href=\"https://example.com\">x</a></h1>", "<a href=\"https://example.com\" rel=\"nofollow\">x</a>"},
+	}
+	for _, test := range tests {
+		r, err := Sanitize(test.policy, test.s)
+		if err != nil || r != test.expected {
+			t.Errorf("sanitize %s %q failed got:%q %v want:%q", test.policy, test.s, r, err, test.expected)
+		}
+	}
+
+	if _, err := Sanitize("missing", "<p>x</p>"); err == nil {
+		t.Errorf("sanitize missing policy failed")
+	}
+	if _, err := Sanitize(); err == nil {
+		t.Errorf("sanitize no args failed")
+	}
+}
@@ -0,0 +1,283 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	got "html/template"
+	"strings"
+	"unicode"
+
+	"github.com/kennygrant/sanitize"
+	"golang.org/x/net/html"
+)
+
+// Ellipsis is the default text appended to truncated strings
+var Ellipsis = "\u2026"
+
+// Truncate text to a given length in characters, breaking at a word boundary if possible,
+// and appending an ellipsis (default Ellipsis) if the text was truncated e.g. {{truncate .Summary 100 "..."}}
+func Truncate(s string, l int64, ellipsis ...string) string {
+	t, truncated := truncateText(s, int(l))
+	if truncated {
+		t += ellipsisArg(ellipsis)
+	}
+	return t
+}
+
+// TruncateHTML truncates the text within html to a given length in characters,
+// closing any tags left open so that the result is balanced.
+// The html should be trusted or sanitized already, as it is returned as got.HTML.
+func TruncateHTML(s interface{}, l int64, ellipsis ...string) got.HTML {
+	var b bytes.Buffer
+	var open []string
+	remaining := int(l)
+
+	z := html.NewTokenizer(strings.NewReader(toText(s)))
+	for remaining >= 0 {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		token := z.Token()
+		switch tt {
+		case html.TextToken:
+			t, truncated := truncateText(token.Data, remaining)
+			b.WriteString(html.EscapeString(t))
+			remaining -= len([]rune(t))
+			if truncated {
+				b.WriteString(html.EscapeString(ellipsisArg(ellipsis)))
+				remaining = -1
+			}
+		case html.StartTagToken:
+			b.WriteString(token.String())
+			if !voidElements[token.Data] {
+				open = append(open, token.Data)
+			}
+		case html.EndTagToken:
+			// Close the most recent matching tag, and any left open within it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						fmt.Fprintf(&b, "</%s>", open[j])
+					}
+					open = open[:i]
+					break
+				}
+			}
+		case html.SelfClosingTagToken:
+			b.WriteString(token.String())
+		}
+	}
+
+	// Close any tags left open
+	for i := len(open) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "</%s>", open[i])
+	}
+
+	return got.HTML(b.String())
+}
+
+// voidElements are html elements which have no end tag
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// smallWords are not capitalised by TitleCase unless they start the title
+var smallWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "but": true, "by": true, "for": true,
+	"in": true, "of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// TitleCase returns the string with the first letter of each word in upper case,
+// except for short words like "of" and "the" after the first word
+func TitleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		if i > 0 && smallWords[strings.ToLower(w)] {
+			words[i] = strings.ToLower(w)
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
+
+// Upper returns the string in upper case
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+// Lower returns the string in lower case
+func Lower(s string) string {
+	return strings.ToLower(s)
+}
+
+// Slugify returns a lower case string suitable for use in urls, with accented characters
+// transliterated to ascii, and other characters replaced by hyphens e.g. Crème Brûlée => creme-brulee
+func Slugify(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(sanitize.Accents(s)) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if hyphen && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+// WordWrap wraps the string at word boundaries so that lines are at most width characters,
+// words longer than width are not broken
+func WordWrap(s string, width int) string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, w := range strings.Fields(para) {
+			switch {
+			case line == "":
+				line = w
+			case len([]rune(line))+1+len([]rune(w)) <= width:
+				line += " " + w
+			default:
+				lines = append(lines, line)
+				line = w
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Nl2br escapes the string, and replaces newlines with <br> tags
+func Nl2br(s string) got.HTML {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	s = strings.Replace(s, "\r", "\n", -1)
+	return got.HTML(strings.Replace(Escape(s), "\n", "<br>", -1))
+}
+
+// Excerpt returns the text around the first match of term (ignoring case), with radius characters
+// either side broken at word boundaries, and an ellipsis (default Ellipsis) where text is cut.
+// If the term is not found, the start of the text is returned.
+func Excerpt(s, term string, radius int, ellipsis ...string) string {
+	runes := []rune(s)
+	i := indexFold(runes, []rune(term), 0)
+	if i < 0 || term == "" {
+		return Truncate(s, int64(radius*2), ellipsis...)
+	}
+
+	start, end := i-radius, i+len([]rune(term))+radius
+	prefix, suffix := "", ""
+	if start > 0 {
+		// Move forward to the start of a word
+		for start < i && !unicode.IsSpace(runes[start-1]) {
+			start++
+		}
+		prefix = ellipsisArg(ellipsis)
+	} else {
+		start = 0
+	}
+	if end < len(runes) {
+		// Move back to the end of a word
+		for end > i+len([]rune(term)) && !unicode.IsSpace(runes[end]) {
+			end--
+		}
+		suffix = ellipsisArg(ellipsis)
+	} else {
+		end = len(runes)
+	}
+
+	return prefix + strings.TrimSpace(string(runes[start:end])) + suffix
+}
+
+// Highlight escapes the string, and wraps matches of term (ignoring case) in <mark> tags
+func Highlight(s, term string) got.HTML {
+	runes, t := []rune(s), []rune(term)
+	if len(t) == 0 {
+		return got.HTML(Escape(s))
+	}
+
+	var b strings.Builder
+	last := 0
+	for i := indexFold(runes, t, 0); i >= 0; i = indexFold(runes, t, last) {
+		b.WriteString(Escape(string(runes[last:i])))
+		b.WriteString("<mark>" + Escape(string(runes[i:i+len(t)])) + "</mark>")
+		last = i + len(t)
+	}
+	b.WriteString(Escape(string(runes[last:])))
+	return got.HTML(b.String())
+}
+
+// truncateText returns s truncated to at most l characters, at the last word boundary
+// within l if there is one, and whether the text was truncated
+func truncateText(s string, l int) (string, bool) {
+	runes := []rune(s)
+	if len(runes) <= l {
+		return s, false
+	}
+	if l <= 0 {
+		return "", true
+	}
+
+	cut := runes[:l]
+	if !unicode.IsSpace(runes[l]) {
+		for i := len(cut) - 1; i > 0; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), unicode.IsSpace), true
+}
+
+// indexFold returns the index in runes of the first match of term after from, ignoring case, or -1
+func indexFold(runes, term []rune, from int) int {
+	if len(term) == 0 {
+		return -1
+	}
+	for i := from; i+len(term) <= len(runes); i++ {
+		match := true
+		for j, r := range term {
+			if unicode.ToLower(runes[i+j]) != unicode.ToLower(r) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// ellipsisArg returns the first optional ellipsis argument or Ellipsis
+func ellipsisArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return Ellipsis
+}
+
+// toText returns a string, got.HTML or other value as a string
+func toText(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case got.HTML:
+		return string(s)
+	case fmt.Stringer:
+		return s.String()
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
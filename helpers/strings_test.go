@@ -0,0 +1,175 @@
+package helpers
+
+import (
+	got "html/template"
+	"testing"
+)
+
+// TestTruncate tests truncation at word boundaries and with multibyte characters
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s        string
+		l        int64
+		ellipsis []string
+		expected string
+	}{
+		{"hello world", 20, nil, "hello world"},
+		{"hello world", 8, nil, "hello…"},
+		{"hello world", 5, []string{"..."}, "hello..."},
+		{"helloworld", 5, []string{""}, "hello"},
+		{"héllo wörld ünïcode", 11, nil, "héllo wörld…"},
+		{"日本語のテキスト", 3, nil, "日本語…"},
+		{"hello", 0, nil, "…"},
+	}
+	for _, test := range tests {
+		if r := Truncate(test.s, test.l, test.ellipsis...); r != test.expected {
+			t.Errorf("truncate %q %d failed got:%q want:%q", test.s, test.l, r, test.expected)
+		}
+	}
+}
+
+// TestTruncateHTML tests html truncation keeps tags balanced
+func TestTruncateHTML(t *testing.T) {
+	tests := []struct {
+		s        interface{}
+		l        int64
+		expected got.HTML
+	}{
+		{"<p>hello <b>world</b></p>", 20, "<p>hello <b>world</b></p>"},
+		{"<p>hello <b>big world</b> again</p>", 9, "<p>hello <b>big…</b></p>"},
+		{got.HTML("<div><p>one</p><p>two three</p></div>"), 6, "<div><p>one</p><p>two…</p></div>"},
+		{"<p>a &amp; b<br>c d</p>", 6, "<p>a &amp; b<br>c…</p>"},
+		{"<p>unclosed <i>tags", 100, "<p>unclosed <i>tags</i></p>"},
+		{`<a href="/x?a=1&amp;b=2">link text</a>`, 4, `<a href="/x?a=1&amp;b=2">link…</a>`},
+	}
+	for _, test := range tests {
+		if r := TruncateHTML(test.s, test.l); r != test.expected {
+			t.Errorf("truncatehtml %q %d failed got:%q want:%q", test.s, test.l, r, test.expected)
+		}
+	}
+}
+
+// TestStringCase tests titlecase, upper and lower
+func TestStringCase(t *testing.T) {
+	if r := TitleCase("the lord of the rings"); r != "The Lord of the Rings" {
+		t.Fatalf("titlecase failed got:%s", r)
+	}
+	if r := TitleCase("élan vital"); r != "Élan Vital" {
+		t.Fatalf("titlecase unicode failed got:%s", r)
+	}
+	if r := Upper("école"); r != "ÉCOLE" {
+		t.Fatalf("upper failed got:%s", r)
+	}
+	if r := Lower("ÉCOLE"); r != "école" {
+		t.Fatalf("lower failed got:%s", r)
+	}
+}
+
+// TestSlugify tests slugs with transliteration
+func TestSlugify(t *testing.T) {
+	tests := [][2]string{
+		{"Hello World", "hello-world"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"  Straße & Co. -- 2024!  ", "strasse-co-2024"},
+		{"Smørrebrød", "smoerrebroed"},
+		{"日本語", ""},
+	}
+	for _, test := range tests {
+		if r := Slugify(test[0]); r != test[1] {
+			t.Errorf("slugify %q failed got:%q want:%q", test[0], r, test[1])
+		}
+	}
+}
+
+// TestInflections tests pluralize and singularize
+func TestInflections(t *testing.T) {
+	tests := [][2]string{
+		{"page", "pages"},
+		{"box", "boxes"},
+		{"category", "categories"},
+		{"day", "days"},
+		{"knife", "knives"},
+		{"half", "halves"},
+		{"analysis", "analyses"},
+		{"status", "statuses"},
+		{"person", "people"},
+		{"child", "children"},
+		{"sheep", "sheep"},
+		{"Matrix", "Matrices"},
+		{"URL", "URLS"},
+	}
+	for _, test := range tests {
+		if r := Pluralize(test[0]); r != test[1] {
+			t.Errorf("pluralize %q failed got:%q want:%q", test[0], r, test[1])
+		}
+		if r := Singularize(test[1]); r != test[0] {
+			t.Errorf("singularize %q failed got:%q want:%q", test[1], r, test[0])
+		}
+	}
+
+	if r := Pluralize("page", 1); r != "page" {
+		t.Fatalf("pluralize count 1 failed got:%s", r)
+	}
+	if r := Pluralize("page", int64(2)); r != "pages" {
+		t.Fatalf("pluralize count 2 failed got:%s", r)
+	}
+}
+
+// TestWordWrap tests wrapping at word boundaries
+func TestWordWrap(t *testing.T) {
+	r := WordWrap("the quick brown fox jumps over\nthe lazy dog", 10)
+	expected := "the quick\nbrown fox\njumps over\nthe lazy\ndog"
+	if r != expected {
+		t.Fatalf("wordwrap failed got:%q", r)
+	}
+	if r := WordWrap("extraordinarily long", 5); r != "extraordinarily\nlong" {
+		t.Fatalf("wordwrap long word failed got:%q", r)
+	}
+}
+
+// TestNl2br tests newlines are replaced after escaping
+func TestNl2br(t *testing.T) {
+	r := Nl2br("<b>one</b>\r\ntwo\nthree")
+	if r != "&lt;b&gt;one&lt;/b&gt;<br>two<br>three" {
+		t.Fatalf("nl2br failed got:%s", r)
+	}
+}
+
+// TestExcerpt tests excerpts around a search term
+func TestExcerpt(t *testing.T) {
+	s := "The quick brown fox jumps over the lazy dog"
+	tests := []struct {
+		term     string
+		radius   int
+		expected string
+	}{
+		{"FOX", 8, "…brown fox jumps…"},
+		{"quick", 100, s},
+		{"the", 4, "The…"},
+		{"cat", 5, "The quick…"},
+	}
+	for _, test := range tests {
+		if r := Excerpt(s, test.term, test.radius); r != test.expected {
+			t.Errorf("excerpt %q failed got:%q want:%q", test.term, r, test.expected)
+		}
+	}
+}
+
+// TestHighlight tests matches are marked and text escaped
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		s        string
+		term     string
+		expected got.HTML
+	}{
+		{"Go is go", "go", "<mark>Go</mark> is <mark>go</mark>"},
+		{"<b>bold</b>", "b", "&lt;<mark>b</mark>&gt;<mark>b</mark>old&lt;/<mark>b</mark>&gt;"},
+		{"café CAFÉ", "café", "<mark>café</mark> <mark>CAFÉ</mark>"},
+		{"no match", "", "no match"},
+	}
+	for _, test := range tests {
+		if r := Highlight(test.s, test.term); r != test.expected {
+			t.Errorf("highlight %q %q failed got:%q want:%q", test.s, test.term, r, test.expected)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"fmt"
+	got "html/template"
+	"time"
+)
+
+// TIME ZONES
+
+// timeZoneKey is the render context key for the time zone, as set by view.NewRenderer
+const timeZoneKey = "time_zone"
+
+// Location returns the location given either a *time.Location or a zone name (e.g. Europe/Paris)
+// it returns nil if the zone is nil, empty or cannot be found
+func Location(zone interface{}) *time.Location {
+	switch z := zone.(type) {
+	case *time.Location:
+		return z
+	case string:
+		if z == "" {
+			return nil
+		}
+		loc, err := time.LoadLocation(z)
+		if err != nil {
+			return nil
+		}
+		return loc
+	}
+	return nil
+}
+
+// zoneLocation returns the location given a *time.Location, zone name or render context containing a time zone,
+// or nil if none is found
+func zoneLocation(zone interface{}) *time.Location {
+	if context, ok := zone.(map[string]interface{}); ok {
+		return Location(context[timeZoneKey])
+	}
+	return Location(zone)
+}
+
+// zoneArgs returns the args given to field helpers without any *time.Location or render context,
+// and the zone they contain if any
+func zoneArgs(args []interface{}) ([]interface{}, *time.Location) {
+	var loc *time.Location
+	var rest []interface{}
+	for _, a := range args {
+		switch a.(type) {
+		case *time.Location, map[string]interface{}:
+			if l := zoneLocation(a); l != nil {
+				loc = l
+			}
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return rest, loc
+}
+
+// InZone returns the time in the given zone, which may be a *time.Location or a zone name
+// if the zone cannot be found, the time is returned in UTC
+func InZone(t time.Time, zone interface{}) time.Time {
+	loc := Location(zone)
+	if loc == nil {
+		return t.UTC()
+	}
+	return t.In(loc)
+}
+
+// LocalTime returns the time in the time zone of the render context given,
+// or the time unchanged if the context has no time zone. Use in templates as {{localtime . .t}}
+func LocalTime(context map[string]interface{}, t time.Time) time.Time {
+	loc := Location(context[timeZoneKey])
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// ZoneTime returns a formatted time string as Time, converted to the zone given as a *time.Location
+// or render context time zone, it is used by the time helper which is passed the render context
+// automatically, e.g. {{time .t}} or {{time .t "15:04" .loc}}
+func ZoneTime(t time.Time, args ...interface{}) got.HTML {
+	layout, loc := timeArgs(args, "Jan 2, 2006 at 15:04")
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return Time(t, layout)
+}
+
+// ZoneDate returns a formatted date string as Date, converted to the zone given as a *time.Location
+// or render context time zone, it is used by the date helper which is passed the render context
+// automatically, e.g. {{date .t}} or {{date .t "2006-01-02" .loc}}
+func ZoneDate(t time.Time, args ...interface{}) got.HTML {
+	layout, loc := timeArgs(args, "Jan 2, 2006")
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return Date(t, layout)
+}
+
+// TimeTag returns a time element with a machine readable datetime attribute,
+// which scripts can use to display the time in the browser's time zone.
+// It accepts the same optional layout, zone or render context arguments as ZoneDate.
+func TimeTag(t time.Time, args ...interface{}) got.HTML {
+	layout, loc := timeArgs(args, "Jan 2, 2006 at 15:04")
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return got.HTML(fmt.Sprintf("<time datetime=\"%s\">%s</time>", t.Format(time.RFC3339), Escape(t.Format(layout))))
+}
+
+// timeArgs reads the optional arguments accepted by date helpers, returning the layout
+// (or defaultLayout if none) and time zone (or nil if none).
+// Strings are used as layouts, and zones may be given as a *time.Location
+// or a render context containing a time zone, e.g. {{date .t "2006-01-02" .}}.
+// A render context is only used if no zone was given before it, so that an explicit zone
+// takes precedence over the render context added by the template parser.
+func timeArgs(args []interface{}, defaultLayout string) (string, *time.Location) {
+	layout := defaultLayout
+	var loc *time.Location
+
+	for _, a := range args {
+		switch v := a.(type) {
+		case string:
+			layout = v
+		case got.HTML:
+			layout = string(v)
+		case *time.Location:
+			loc = v
+		case map[string]interface{}:
+			if loc == nil {
+				loc = Location(v[timeZoneKey])
+			}
+		}
+	}
+
+	return layout, loc
+}
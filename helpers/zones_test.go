@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+// TestZones tests converting times to zones in date helpers
+func TestZones(t *testing.T) {
+	d := time.Date(2016, time.March, 7, 23, 30, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("zone data not available:%s", err)
+	}
+	context := map[string]interface{}{timeZoneKey: paris}
+
+	tests := [][2]string{
+		{string(Date(d)), "Mar 7, 2016"},
+		{string(ZoneDate(d, context)), "Mar 8, 2016"},
+		{string(ZoneDate(d, "2006-01-02 15:04", paris)), "2016-03-08 00:30"},
+		{string(ZoneDate(d, "15:04", time.UTC, context)), "23:30"},
+		{string(ZoneTime(d, map[string]interface{}{})), "Mar 7, 2016 at 23:30"},
+		{string(LangDate("fr", d, context)), "8 mars 2016"},
+		{InZone(d, "Europe/Paris").Format("15:04"), "00:30"},
+		{InZone(d, "Nowhere/Unknown").Format("15:04 MST"), "23:30 UTC"},
+		{LocalTime(context, d).Format("15:04"), "00:30"},
+		{string(TimeTag(d, context)), `<time datetime="2016-03-08T00:30:00+01:00">Mar 8, 2016 at 00:30</time>`},
+	}
+
+	for _, test := range tests {
+		if test[0] != test[1] {
+			t.Errorf("zone failed got:%s expected:%s", test[0], test[1])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	htmltemplate "html/template"
+	"reflect"
+	texttemplate "text/template"
+	"text/template/parse"
+)
+
+// ContextHelpers lists helpers which are passed the render context ($) as their last argument,
+// so that they can read values such as the request time zone without templates passing them,
+// e.g. {{date .t}} is rendered as {{date .t $}}. Only helpers called at the start of a pipeline
+// are passed the context, as piped values are passed last. It is read when templates are scanned,
+// and helpers listed here are only passed the context if their func accepts ...interface{}.
+var ContextHelpers []string
+
+// contextFuncs holds the names of ContextHelpers which accept the context in the helpers being parsed
+var contextFuncs map[string]bool
+
+// setContextFuncs sets the ContextHelpers which accept the render context in the helpers given,
+// so that apps may replace them with helpers which do not
+func setContextFuncs(helpers FuncMap) {
+	contextFuncs = make(map[string]bool)
+	for _, name := range ContextHelpers {
+		if acceptsContext(helpers[name]) {
+			contextFuncs[name] = true
+		}
+	}
+}
+
+// acceptsContext returns true if f is a variadic func accepting interface{} as its last argument
+func acceptsContext(f interface{}) bool {
+	if f == nil {
+		return false
+	}
+	t := reflect.TypeOf(f)
+	if t.Kind() != reflect.Func || !t.IsVariadic() {
+		return false
+	}
+	last := t.In(t.NumIn() - 1).Elem()
+	return last.Kind() == reflect.Interface && last.NumMethod() == 0
+}
+
+// addContextHTML adds the render context to calls to ContextHelpers in the html template set given
+func addContextHTML(set *htmltemplate.Template) {
+	for _, t := range set.Templates() {
+		addContext(t.Tree)
+	}
+}
+
+// addContextText adds the render context to calls to ContextHelpers in the text template set given
+func addContextText(set *texttemplate.Template) {
+	for _, t := range set.Templates() {
+		addContext(t.Tree)
+	}
+}
+
+// addContext adds the render context to calls to ContextHelpers in the tree given,
+// calls which already end with $ are left unchanged
+func addContext(tree *parse.Tree) {
+	if tree == nil || len(contextFuncs) == 0 {
+		return
+	}
+	addContextNode(tree.Root)
+}
+
+// addContextNode adds the render context to calls to ContextHelpers in node and its children
+func addContextNode(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			addContextNode(c)
+		}
+	case *parse.ActionNode:
+		addContextPipe(n.Pipe)
+	case *parse.TemplateNode:
+		addContextPipe(n.Pipe)
+	case *parse.IfNode:
+		addContextBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		addContextBranch(&n.BranchNode)
+	case *parse.WithNode:
+		addContextBranch(&n.BranchNode)
+	}
+}
+
+// addContextBranch adds the render context to calls in the pipeline and lists of an if, range or with
+func addContextBranch(n *parse.BranchNode) {
+	addContextPipe(n.Pipe)
+	addContextNode(n.List)
+	if n.ElseList != nil {
+		addContextNode(n.ElseList)
+	}
+}
+
+// addContextPipe adds the render context to calls in the pipeline, including those in parentheses
+func addContextPipe(pipe *parse.PipeNode) {
+	if pipe == nil {
+		return
+	}
+	for i, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			if p, ok := arg.(*parse.PipeNode); ok {
+				addContextPipe(p)
+			}
+		}
+		if i > 0 || len(cmd.Args) == 0 {
+			continue
+		}
+		ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+		if !ok || !contextHelper(ident.Ident) {
+			continue
+		}
+		if v, ok := cmd.Args[len(cmd.Args)-1].(*parse.VariableNode); ok && v.String() == "$" {
+			continue
+		}
+		cmd.Args = append(cmd.Args, &parse.VariableNode{NodeType: parse.NodeVariable, Pos: ident.Pos, Ident: []string{"$"}})
+	}
+}
+
+// contextHelper returns true if name is a ContextHelper which accepts the render context
+func contextHelper(name string) bool {
+	return contextFuncs[name]
+}
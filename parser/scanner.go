@@ -33,7 +33,7 @@ func NewScanner(paths []string, helpers FuncMap) (*Scanner, error) {
 		Helpers:   helpers,
 		Paths:     paths,
 		Templates: make(map[string]Template),
-		Parsers:   []Parser{new(JSONTemplate), new(HTMLTemplate), new(TextTemplate)},
+		Parsers:   []Parser{new(JSONTemplate), new(HTMLTemplate), new(TextTemplate), new(MarkdownTemplate)},
 	}
 
 	return s, nil
@@ -153,6 +153,9 @@ func (s *Scanner) ScanPaths() error {
 	// Make sure templates is empty
 	s.Templates = make(map[string]Template)
 
+	// Find the helpers which are passed the render context
+	setContextFuncs(s.Helpers)
+
 	// Set up the parsers
 	for _, p := range s.Parsers {
 		err := p.Setup(s.Helpers)
@@ -51,6 +51,9 @@ func (t *HTMLTemplate) Parse() error {
 	// Add to our template set - NB duplicates not allowed by golang templates
 	if htmlTemplateSet.Lookup(t.Path()) == nil {
 		_, err = htmlTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextHTML(htmlTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
@@ -67,6 +70,9 @@ func (t *HTMLTemplate) ParseString(s string) error {
 	// Add to our template set
 	if htmlTemplateSet.Lookup(t.Path()) == nil {
 		_, err = htmlTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextHTML(htmlTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
@@ -97,10 +103,12 @@ func (t *HTMLTemplate) Finalize(templates map[string]Template) error {
 }
 
 // Render the template to the given writer, returning an error
+// The lock is not held during execution, as templates may render partials (see helpers.Partial),
+// and the template set is replaced rather than modified on reload.
 func (t *HTMLTemplate) Render(writer io.Writer, context map[string]interface{}) error {
 	mu.RLock()
-	defer mu.RUnlock()
 	tmpl := htmlTemplateSet.Lookup(t.Path())
+	mu.RUnlock()
 	if tmpl == nil {
 		return fmt.Errorf("#error loading template for %s", t.Path())
 	}
@@ -48,6 +48,9 @@ func (t *JSONTemplate) Parse() error {
 	// Add to our template set
 	if jsonTemplateSet.Lookup(t.Path()) == nil {
 		_, err = jsonTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextHTML(jsonTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
@@ -65,6 +68,9 @@ func (t *JSONTemplate) ParseString(s string) error {
 	// Add to our template set
 	if jsonTemplateSet.Lookup(t.Path()) == nil {
 		_, err = jsonTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextHTML(jsonTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	got "text/template"
+)
+
+var (
+	markdownMu          sync.RWMutex  // Shared mutex to go with shared template set, because of dev reloads
+	markdownTemplateSet *got.Template // This is a shared template set for markdown templates
+)
+
+// MarkdownRenderer converts the markdown output of markdown templates to html,
+// it is set by view to helpers.RenderMarkdown, which sanitizes the html
+var MarkdownRenderer func(src []byte) (string, error)
+
+// MarkdownTemplate represents a markdown template using go text/template,
+// the template is executed and the result converted to html with MarkdownRenderer.
+// It may be used as the template within an html layout.
+type MarkdownTemplate struct {
+	BaseTemplate
+}
+
+// Setup performs setup before parsing templates
+func (t *MarkdownTemplate) Setup(helpers FuncMap) error {
+	markdownMu.Lock()
+	defer markdownMu.Unlock()
+	markdownTemplateSet = got.New("").Funcs(got.FuncMap(helpers))
+	return nil
+}
+
+// CanParseFile returns true if this parser handles this path
+func (t *MarkdownTemplate) CanParseFile(path string) bool {
+	allowed := []string{".md.got"}
+	return suffixes(path, allowed)
+}
+
+// NewTemplate returns a new template for this type
+func (t *MarkdownTemplate) NewTemplate(fullpath, path string) (Template, error) {
+	template := new(MarkdownTemplate)
+	template.fullpath = fullpath
+	template.path = path
+	return template, nil
+}
+
+// Parse the template at path
+func (t *MarkdownTemplate) Parse() error {
+	markdownMu.Lock()
+	defer markdownMu.Unlock()
+	err := t.BaseTemplate.Parse()
+	if err != nil {
+		return err
+	}
+
+	// Add to our template set - NB duplicates not allowed by golang templates
+	if markdownTemplateSet.Lookup(t.Path()) == nil {
+		_, err = markdownTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextText(markdownTemplateSet)
+		}
+	} else {
+		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
+	}
+
+	return err
+}
+
+// ParseString parses a string template
+func (t *MarkdownTemplate) ParseString(s string) error {
+	markdownMu.Lock()
+	defer markdownMu.Unlock()
+	err := t.BaseTemplate.ParseString(s)
+	if err != nil {
+		return err
+	}
+
+	// Add to our template set
+	if markdownTemplateSet.Lookup(t.Path()) == nil {
+		_, err = markdownTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextText(markdownTemplateSet)
+		}
+	} else {
+		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
+	}
+
+	return err
+}
+
+// Finalize the template set, called after parsing is complete
+// Record a list of dependent templates (for breaking caches automatically)
+func (t *MarkdownTemplate) Finalize(templates map[string]Template) error {
+
+	// Search source for {{\s template "|`xxx`|" x }} pattern
+	paths := templateInclude.FindAllStringSubmatch(t.Source(), -1)
+
+	// For all includes found, add the template to our dependency list
+	for _, p := range paths {
+		d := templates[p[1]]
+		if d != nil {
+			t.dependencies = append(t.dependencies, d)
+		}
+	}
+
+	return nil
+}
+
+// Render executes the template, and writes the result converted from markdown to html
+func (t *MarkdownTemplate) Render(writer io.Writer, context map[string]interface{}) error {
+	markdownMu.RLock()
+	tmpl := markdownTemplateSet.Lookup(t.Path())
+	markdownMu.RUnlock()
+	if tmpl == nil {
+		return fmt.Errorf("#error loading template for %s", t.Path())
+	}
+	if MarkdownRenderer == nil {
+		return fmt.Errorf("#error no markdown renderer for %s", t.Path())
+	}
+
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, context)
+	if err != nil {
+		return err
+	}
+
+	html, err := MarkdownRenderer(b.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, html)
+	return err
+}
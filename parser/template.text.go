@@ -40,6 +40,9 @@ func (t *TextTemplate) Parse() error {
 	// Add to our template set
 	if textTemplateSet.Lookup(t.path) == nil {
 		_, err = textTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextText(textTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
@@ -54,6 +57,9 @@ func (t *TextTemplate) ParseString(s string) error {
 	// Add to our template set
 	if textTemplateSet.Lookup(t.Path()) == nil {
 		_, err = textTemplateSet.New(t.path).Parse(t.Source())
+		if err == nil {
+			addContextText(textTemplateSet)
+		}
 	} else {
 		err = fmt.Errorf("Duplicate template:%s %s", t.Path(), t.Source())
 	}
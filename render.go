@@ -10,6 +10,8 @@ import (
 	"path"
 	"regexp"
 	"strings"
+
+	"github.com/fragmenta/view/helpers"
 )
 
 // Renderer is a view which is set up on each request and renders the response to its writer
@@ -35,6 +37,9 @@ type Renderer struct {
 
 	// The request path
 	path string
+
+	// The form validation errors, kept if the context is replaced
+	errors helpers.FormErrors
 }
 
 type ctxKey struct {
@@ -50,8 +55,27 @@ var AuthenticityContext = &ctxKey{authenticityKey}
 var authenticityKey = "authenticity_token"
 
 // LanguageContext is used as a key to save request lang
-var LanguageContext = &ctxKey{languageKey}
-var languageKey = "lang"
+var LanguageContext = &ctxKey{LanguageKey}
+
+// LanguageKey is used to save the request lang in the render context
+const LanguageKey = "lang"
+
+// TimeZoneContext is used as a key to save the request time zone,
+// either as a *time.Location or a zone name such as Europe/Paris
+var TimeZoneContext = &ctxKey{timeZoneKey}
+var timeZoneKey = "time_zone"
+
+// PathKey is used to save the request path in the render context,
+// so that helpers can build urls for the current page
+const PathKey = "request_path"
+
+// errorsKey is used to save form validation errors in the render context,
+// so that form helpers can show them with fields
+var errorsKey = "form_errors"
+
+// TemplateKey is used to save the path of the template being rendered in the render context,
+// so that helpers can report which template used them, it is namespaced to avoid clashing with app keys
+const TemplateKey = "view.template_path"
 
 // NewRenderer returns a new renderer for this request.
 func NewRenderer(w http.ResponseWriter, r *http.Request) *Renderer {
@@ -68,6 +92,7 @@ func NewRenderer(w http.ResponseWriter, r *http.Request) *Renderer {
 	if r != nil {
 		// Read the path from request
 		renderer.path = canonicalPath(r)
+		renderer.context[PathKey] = renderer.path
 
 		// Extract the authenticity token (if any) from context
 		token := r.Context().Value(AuthenticityContext)
@@ -78,7 +103,13 @@ func NewRenderer(w http.ResponseWriter, r *http.Request) *Renderer {
 		// Extract the language (if any) from context
 		lang := r.Context().Value(LanguageContext)
 		if lang != nil {
-			renderer.context[languageKey] = lang.(string)
+			renderer.context[LanguageKey] = lang.(string)
+		}
+
+		// Extract the time zone (if any) from context, for use by date helpers
+		loc := helpers.Location(r.Context().Value(TimeZoneContext))
+		if loc != nil {
+			renderer.context[timeZoneKey] = loc
 		}
 	}
 
@@ -151,9 +182,30 @@ func (r *Renderer) AddKey(key string, value interface{}) *Renderer {
 	return r
 }
 
-// Context sets the entire context for rendering
+// Errors sets the form validation errors shown by form helpers,
+// they are kept if the context is replaced or merged afterwards
+func (r *Renderer) Errors(errors helpers.FormErrors) *Renderer {
+	r.errors = errors
+	r.context[errorsKey] = errors
+	return r
+}
+
+// Context sets the entire context for rendering, replacing request values and keys set before,
+// form errors set with Errors are kept
 func (r *Renderer) Context(c map[string]interface{}) *Renderer {
 	r.context = c
+	if r.errors != nil {
+		r.context[errorsKey] = r.errors
+	}
+	return r
+}
+
+// MergeContext sets the keys given in the context for rendering,
+// keeping request values, form errors and keys set before
+func (r *Renderer) MergeContext(c map[string]interface{}) *Renderer {
+	for k, v := range c {
+		r.context[k] = v
+	}
 	return r
 }
 
@@ -171,6 +223,7 @@ func (r *Renderer) RenderToString() (string, error) {
 		}
 
 		var rendered bytes.Buffer
+		r.context[TemplateKey] = r.template
 		err := t.Render(&rendered, r.context)
 		if err != nil {
 			return content, err
@@ -198,6 +251,7 @@ func (r *Renderer) RenderToStringWithLayout() (string, error) {
 		}
 
 		// Render the template to a buffer
+		r.context[TemplateKey] = r.template
 		err := t.Render(&rendered, r.context)
 		if err != nil {
 			return "", err
@@ -216,6 +270,7 @@ func (r *Renderer) RenderToStringWithLayout() (string, error) {
 
 			// Render the layout to the buffer
 			rendered.Reset()
+			r.context[TemplateKey] = r.layout
 			err := l.Render(&rendered, r.context)
 			if err != nil {
 				return "", err
@@ -258,6 +313,7 @@ func (r *Renderer) Render() error {
 		}
 
 		var rendered bytes.Buffer
+		r.context[TemplateKey] = r.template
 		err := t.Render(&rendered, r.context)
 		if err != nil {
 			return fmt.Errorf("#error Could not render template %s - %s", r.template, err)
@@ -279,6 +335,7 @@ func (r *Renderer) Render() error {
 			return fmt.Errorf("#error Could not find layout %s", r.layout)
 		}
 
+		r.context[TemplateKey] = r.layout
 		err := layout.Render(r.writer, r.context)
 		if err != nil {
 			return fmt.Errorf("#error Could not render layout %s %s", r.layout, err)
@@ -0,0 +1,227 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template/parse"
+
+	"github.com/fragmenta/view/parser"
+)
+
+// ExtractHelpers lists the names of template helpers whose string arguments are translation keys
+var ExtractHelpers = []string{"t", "translate"}
+
+// Extract scans the template paths given, using the same parsers as parser.Scanner,
+// and returns a sorted list of translation keys used in calls to ExtractHelpers
+func Extract(paths []string) ([]string, error) {
+	s, err := parser.NewScanner(paths, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for _, root := range s.Paths {
+		err = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !canScanFile(s.Parsers, p) {
+				return nil
+			}
+			return extractFile(p, keys)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var list []string
+	for k := range keys {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// MergeResult reports the changes made when merging keys into a lang file
+type MergeResult struct {
+	Added    []string
+	Obsolete []string
+}
+
+// Merge reads the lang file at p (if any), adds any of keys not present with an empty translation,
+// and writes it back, preserving existing translations. Keys in the file but not in keys
+// are reported as obsolete, and removed only if prune is true.
+func Merge(p string, keys []string, prune bool) (MergeResult, error) {
+	var result MergeResult
+
+	langData, err := readLangFile(p)
+	if err != nil {
+		return result, err
+	}
+
+	used := make(map[string]bool)
+	for _, k := range keys {
+		used[k] = true
+		if _, ok := langData[k]; !ok {
+			langData[k] = ""
+			result.Added = append(result.Added, k)
+		}
+	}
+
+	for k := range langData {
+		if !used[k] {
+			result.Obsolete = append(result.Obsolete, k)
+			if prune {
+				delete(langData, k)
+			}
+		}
+	}
+	sort.Strings(result.Obsolete)
+
+	// Keys are sorted by json.MarshalIndent, which keeps diffs minimal
+	file, err := json.MarshalIndent(langData, "", "    ")
+	if err != nil {
+		return result, err
+	}
+
+	return result, ioutil.WriteFile(p, append(file, '\n'), 0644)
+}
+
+// Check reads the lang file at p, and returns the keys which are missing or untranslated
+func Check(p string, keys []string) ([]string, error) {
+	langData, err := readLangFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, k := range keys {
+		if langData[k] == "" {
+			missing = append(missing, k)
+		}
+	}
+	return missing, nil
+}
+
+// readLangFile reads the lang file at p, returning an empty map if it does not exist
+func readLangFile(p string) (map[string]string, error) {
+	langData := make(map[string]string)
+
+	file, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return langData, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error opening file %s %v", p, err)
+	}
+
+	err = json.Unmarshal(file, &langData)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading language file %s %v", p, err)
+	}
+
+	return langData, nil
+}
+
+// canScanFile returns true if one of the parsers would handle this file
+func canScanFile(parsers []parser.Parser, p string) bool {
+	for _, pr := range parsers {
+		if pr.CanParseFile(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractFile parses the template file at p and adds translation keys found to keys
+func extractFile(p string, keys map[string]bool) error {
+	source, err := ioutil.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("Error opening file %s %v", p, err)
+	}
+
+	// Parse the template without helpers, as we only need the tree
+	tree := parse.New(p)
+	tree.Mode = parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	_, err = tree.Parse(string(source), "", "", trees)
+	if err != nil {
+		return fmt.Errorf("Error parsing template %s %v", p, err)
+	}
+
+	for _, t := range trees {
+		if t.Root != nil {
+			extractNode(t.Root, keys)
+		}
+	}
+
+	return nil
+}
+
+// extractNode walks the template tree from node, adding translation keys found to keys
+func extractNode(node parse.Node, keys map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			extractNode(c, keys)
+		}
+	case *parse.ActionNode:
+		extractNode(n.Pipe, keys)
+	case *parse.IfNode:
+		extractBranch(&n.BranchNode, keys)
+	case *parse.RangeNode:
+		extractBranch(&n.BranchNode, keys)
+	case *parse.WithNode:
+		extractBranch(&n.BranchNode, keys)
+	case *parse.TemplateNode:
+		extractNode(n.Pipe, keys)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			extractNode(c, keys)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) > 0 && isExtractHelper(n.Args[0]) {
+			for _, a := range n.Args[1:] {
+				if s, ok := a.(*parse.StringNode); ok {
+					keys[s.Text] = true
+				}
+			}
+		}
+		for _, a := range n.Args {
+			extractNode(a, keys)
+		}
+	}
+}
+
+// extractBranch walks the pipe and lists of an if, range or with node
+func extractBranch(n *parse.BranchNode, keys map[string]bool) {
+	extractNode(n.Pipe, keys)
+	extractNode(n.List, keys)
+	if n.ElseList != nil {
+		extractNode(n.ElseList, keys)
+	}
+}
+
+// isExtractHelper returns true if this node is an identifier for one of ExtractHelpers
+func isExtractHelper(node parse.Node) bool {
+	ident, ok := node.(*parse.IdentifierNode)
+	if !ok {
+		return false
+	}
+	for _, h := range ExtractHelpers {
+		if ident.Ident == h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package translation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestExtract tests extracting keys from templates and merging them into lang files
+func TestExtract(t *testing.T) {
+	keys, err := Extract([]string{"test_data/templates"})
+	if err != nil {
+		t.Fatalf("Extract failed:%s", err)
+	}
+
+	expected := []string{"body", "empty", "item", "title"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Extract failed got:%v expected:%v", keys, expected)
+	}
+
+	dir, err := ioutil.TempDir("", "view-i18n")
+	if err != nil {
+		t.Fatalf("Temp dir failed:%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "fr.lang.json")
+	err = ioutil.WriteFile(p, []byte(`{"title":"Titre","old":"Vieux"}`), 0644)
+	if err != nil {
+		t.Fatalf("Write failed:%s", err)
+	}
+
+	result, err := Merge(p, keys, false)
+	if err != nil {
+		t.Fatalf("Merge failed:%s", err)
+	}
+	if len(result.Added) != 3 || !reflect.DeepEqual(result.Obsolete, []string{"old"}) {
+		t.Fatalf("Merge failed got:%v", result)
+	}
+
+	missing, err := Check(p, keys)
+	if err != nil {
+		t.Fatalf("Check failed:%s", err)
+	}
+	if !reflect.DeepEqual(missing, []string{"body", "empty", "item"}) {
+		t.Fatalf("Check failed got:%v", missing)
+	}
+
+	langData, err := readLangFile(p)
+	if err != nil || langData["title"] != "Titre" || langData["old"] != "Vieux" {
+		t.Fatalf("Merge failed to preserve translations got:%v", langData)
+	}
+}
@@ -0,0 +1,15 @@
+package translation
+
+import (
+	"github.com/fragmenta/view"
+	"github.com/fragmenta/view/parser"
+)
+
+// The translation helpers are registered with view, which cannot import this package
+func init() {
+	view.RegisterHelpers(parser.FuncMap{
+		"translate":      Translate,
+		"localurl":       LocalURL,
+		"alternatelinks": PageAlternateLinks,
+	})
+}
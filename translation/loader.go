@@ -0,0 +1,152 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// File holds the translations read from a single translation file by a Loader
+type File struct {
+	// Lang is the language set in file metadata, if any
+	Lang string
+
+	// PluralForms is the plural forms expression set in file metadata, if any
+	// e.g. nplurals=2; plural=(n != 1);
+	PluralForms string
+
+	// Translations is a flat map of translations keyed by key
+	Translations map[string]string
+}
+
+// Loader reads translation files of a given format
+type Loader interface {
+	// Can this loader handle this file?
+	CanLoadFile(path string) bool
+
+	// Load the file contents given and return the translations
+	Load(b []byte) (*File, error)
+}
+
+// Loaders is the list of loaders (in order) used to read translation files,
+// the first loader which can load a file wins
+var Loaders = []Loader{new(JSONLoader), new(POLoader), new(MOLoader), new(YAMLLoader), new(TOMLLoader)}
+
+// LocaleKey is the key used for the language in the metadata of json, yaml and toml files
+const LocaleKey = "@@locale"
+
+// PluralFormsKey is the key used for the plural forms expression in the metadata of json, yaml and toml files
+const PluralFormsKey = "@@plural_forms"
+
+// loaderFor returns the loader for the file at p, or nil if none can load it
+func loaderFor(p string) Loader {
+	if strings.HasPrefix(path.Base(p), ".") {
+		return nil
+	}
+	for _, l := range Loaders {
+		if l.CanLoadFile(p) {
+			return l
+		}
+	}
+	return nil
+}
+
+// langFromPath returns the language given in the file name, e.g. fr for fr.lang.json
+func langFromPath(p string) string {
+	return strings.SplitN(path.Base(p), ".", 2)[0]
+}
+
+// JSONLoader loads .lang.json files containing either flat keys,
+// or nested objects which are flattened to dot separated keys (nav.home)
+type JSONLoader struct{}
+
+// CanLoadFile returns true if this loader handles this file
+func (l *JSONLoader) CanLoadFile(p string) bool {
+	return strings.HasSuffix(p, ".lang.json")
+}
+
+// Load returns the translations in the json file contents
+func (l *JSONLoader) Load(b []byte) (*File, error) {
+	var values map[string]interface{}
+	err := json.Unmarshal(b, &values)
+	if err != nil {
+		return nil, err
+	}
+	return newNestedFile(values)
+}
+
+// newNestedFile returns a file from nested values, reading the language from LocaleKey
+// and the plural forms from PluralFormsKey
+func newNestedFile(values map[string]interface{}) (*File, error) {
+	f := &File{Translations: make(map[string]string)}
+
+	if lang, ok := values[LocaleKey].(string); ok {
+		f.Lang = lang
+		delete(values, LocaleKey)
+	}
+
+	if forms, ok := values[PluralFormsKey].(string); ok {
+		f.PluralForms = forms
+		delete(values, PluralFormsKey)
+	}
+
+	err := flatten(f.Translations, "", values)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// flatten adds the nested value v to translations with dot separated keys,
+// arrays are stored with indexed keys key[0], key[1] as used for plural forms
+func flatten(translations map[string]string, key string, v interface{}) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		// Sort keys so that errors are reported consistently
+		var keys []string
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			err := flatten(translations, joinKey(key, k), value[k])
+			if err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, kv := range value {
+			m[fmt.Sprintf("%v", k)] = kv
+		}
+		return flatten(translations, key, m)
+	case []interface{}:
+		for i, iv := range value {
+			err := flatten(translations, fmt.Sprintf("%s[%d]", key, i), iv)
+			if err != nil {
+				return err
+			}
+		}
+	case string:
+		translations[key] = value
+	case nil:
+		translations[key] = ""
+	case bool, int, int64, uint64, float64:
+		translations[key] = fmt.Sprintf("%v", value)
+	default:
+		return fmt.Errorf("unexpected value for key %s %v", key, v)
+	}
+
+	return nil
+}
+
+// joinKey returns the prefix and key joined with a dot
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,93 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// TestLoaders tests loading po, yaml, toml and nested json files
+func TestLoaders(t *testing.T) {
+	loadTestData(t)
+
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{Get("de", "foo"), "Balken"},
+		{GetContext("de", "menu", "open"), "Öffnen"},
+		{GetContext("de", "menu", "shut"), "shut"},
+		{Get("de", "fuzzy"), "fuzzy"},
+		{GetPlural("de", "apple", 1), "ein Apfel"},
+		{GetPlural("de", "apple", 5), "Äpfel"},
+		{GetPlural("fr", "apple", 5), "apple"},
+		{Get("ru", "nav.home"), "Главная"},
+		{Get("ru", "apple[2]"), "яблок"},
+		{GetPlural("ru", "apple", 1), "яблоко"},
+		{GetPlural("ru", "apple", 3), "яблока"},
+		{GetPlural("ru", "apple", 5), "яблок"},
+		{GetPlural("ru", "apple", 21), "яблоко"},
+		{Get("es", "nav.home"), "Inicio"},
+		{Get("pl", "nav.home"), "Strona główna"},
+	}
+
+	for i, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("translation %d failed got:%s expected:%s", i, test.got, test.expected)
+		}
+	}
+}
+
+// TestMOLoader tests loading a compiled mo file
+func TestMOLoader(t *testing.T) {
+	originals := []string{"", "apple\x00apples", "menu\x04open"}
+	translations := []string{"Language: de\nPlural-Forms: nplurals=2; plural=n != 1;\n", "ein Apfel\x00Äpfel", "Öffnen"}
+
+	// Write the header and tables, followed by the strings
+	var b bytes.Buffer
+	n := uint32(len(originals))
+	offset := 28 + 16*n
+	header := []uint32{0x950412de, 0, n, 28, 28 + 8*n, 0, 0}
+	binary.Write(&b, binary.LittleEndian, header)
+	for _, strs := range [][]string{originals, translations} {
+		for _, s := range strs {
+			binary.Write(&b, binary.LittleEndian, []uint32{uint32(len(s)), offset})
+			offset += uint32(len(s))
+		}
+	}
+	for _, strs := range [][]string{originals, translations} {
+		for _, s := range strs {
+			b.WriteString(s)
+		}
+	}
+
+	f, err := new(MOLoader).Load(b.Bytes())
+	if err != nil {
+		t.Fatalf("Load mo failed:%s", err)
+	}
+
+	if f.Lang != "de" || f.PluralForms == "" || f.Translations["apple[1]"] != "Äpfel" || f.Translations["menu\x04open"] != "Öffnen" {
+		t.Fatalf("Load mo failed got:%v", f)
+	}
+}
+
+// TestPluralForms tests evaluating plural forms expressions
+func TestPluralForms(t *testing.T) {
+	// Polish has three forms
+	rule, err := parsePluralForms("nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);")
+	if err != nil {
+		t.Fatalf("Parse plural forms failed:%s", err)
+	}
+
+	tests := map[int]int{1: 0, 2: 1, 4: 1, 5: 2, 12: 2, 22: 1, 25: 2, 0: 2}
+	for n, expected := range tests {
+		if rule(n) != expected {
+			t.Errorf("plural rule failed for %d got:%d expected:%d", n, rule(n), expected)
+		}
+	}
+
+	_, err = parsePluralForms("nplurals=2; plural=(n != ;")
+	if err == nil {
+		t.Errorf("plural forms failed to report error")
+	}
+}
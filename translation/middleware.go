@@ -47,6 +47,52 @@ func Middleware(h http.HandlerFunc) http.HandlerFunc {
 
 }
 
+// PrefixMiddleware strips a supported language prefix from the request path
+// before routing, so that /fr/pricing is served by the handler for /pricing,
+// and saves the language to the request context for use in views.
+// Requests without a prefix fall back to the cookie and Accept-Language header.
+func PrefixMiddleware(h http.HandlerFunc) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		lang, p := splitPrefix(r.URL.Path)
+		if lang == "" {
+			Middleware(h)(w, r)
+			return
+		}
+
+		// Save the language to the request context for use in views
+		ctx := r.Context()
+		ctx = context.WithValue(ctx, view.LanguageContext, lang)
+		r = r.WithContext(ctx)
+
+		// Copy the url before altering the path, as it is shared with the original request
+		u := *r.URL
+		u.Path = p
+		u.RawPath = ""
+		r.URL = &u
+
+		h(w, r)
+	}
+
+}
+
+// splitPrefix returns the supported language prefix (if any) from the path
+// and the path with that prefix removed, so /fr/pricing becomes fr, /pricing
+func splitPrefix(p string) (string, string) {
+	trimmed := strings.TrimPrefix(p, "/")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts[0]) == 0 || !Supported(parts[0]) {
+		return "", p
+	}
+
+	if len(parts) == 1 {
+		return parts[0], "/"
+	}
+
+	return parts[0], "/" + parts[1]
+}
+
 // requestLang returns the language in the request Accept-Language header
 // only the preferred first language is returned
 // headers of form en-US,en;q=0.8,ro;q=0.6
@@ -0,0 +1,72 @@
+package translation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fragmenta/view"
+)
+
+// TestPrefixMiddleware tests stripping of language prefixes from the path
+func TestPrefixMiddleware(t *testing.T) {
+	loadTestData(t)
+
+	tests := map[string][2]string{
+		"/fr/pricing": {"fr", "/pricing"},
+		"/fr":         {"fr", "/"},
+		"/en/pricing": {"en", "/pricing"},
+		"/it/pricing": {DefaultLanguage, "/it/pricing"},
+		"/pricing":    {DefaultLanguage, "/pricing"},
+	}
+
+	for p, expected := range tests {
+		var lang, path string
+		h := PrefixMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			lang, _ = r.Context().Value(view.LanguageContext).(string)
+			path = r.URL.Path
+		})
+
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, p, nil))
+		if lang != expected[0] || path != expected[1] {
+			t.Errorf("prefix middleware failed for %s got:%s %s expected:%s %s", p, lang, path, expected[0], expected[1])
+		}
+	}
+}
+
+// TestURL tests building localised urls and alternate links
+func TestURL(t *testing.T) {
+	loadTestData(t)
+
+	tests := map[string]string{
+		"fr /pricing":     "/fr/pricing",
+		"fr /fr/pricing":  "/fr/pricing",
+		"fr /":            "/fr",
+		"fr /pricing/":    "/fr/pricing/",
+		"en /fr/pricing":  "/pricing",
+		"en /fr/pricing/": "/pricing/",
+	}
+
+	for args, expected := range tests {
+		a := strings.Split(args, " ")
+		u := URL(a[0], a[1])
+		if u != expected {
+			t.Errorf("url failed for %s got:%s expected:%s", args, u, expected)
+		}
+	}
+
+	links := string(AlternateLinks("https://example.com/", "/pricing"))
+	if !strings.Contains(links, `hreflang="fr" href="https://example.com/fr/pricing"`) ||
+		!strings.Contains(links, `hreflang="x-default" href="https://example.com/pricing"`) {
+		t.Errorf("alternate links failed got:%s", links)
+	}
+
+	context := map[string]interface{}{view.PathKey: "/fr/pricing"}
+	if u := LocalURL(context, "de"); u != "/de/pricing" {
+		t.Errorf("local url failed got:%s", u)
+	}
+	if view.DefaultHelpers()["localurl"] == nil || view.Helpers["alternatelinks"] == nil {
+		t.Errorf("url helpers not registered")
+	}
+}
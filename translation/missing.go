@@ -0,0 +1,127 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	got "html/template"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/fragmenta/view"
+)
+
+// RecordMissing determines whether requests for missing translations are recorded,
+// it should be set on startup, usually in development only
+var RecordMissing = false
+
+// HighlightMissing determines whether Translate wraps missing translations in a span
+// with class translation-missing, so that they are visible in development
+var HighlightMissing = false
+
+// MissingTranslation records a key requested for a language without a translation
+type MissingTranslation struct {
+	Lang      string   `json:"lang"`
+	Key       string   `json:"key"`
+	Templates []string `json:"templates,omitempty"`
+	Count     int      `json:"count"`
+}
+
+// missing holds the missing translations recorded, keyed by lang+key
+var missing = make(map[string]*MissingTranslation)
+
+// missingMu guards missing translations
+var missingMu sync.Mutex
+
+// Translate returns the translation for key in the language of the render context,
+// recording the template which requested it if the translation is missing.
+// Use in templates as {{translate . "key"}}
+func Translate(context map[string]interface{}, key string) got.HTML {
+	lang, _ := context[view.LanguageKey].(string)
+	if lang == "" {
+		lang = DefaultLanguage
+	}
+	template, _ := context[view.TemplateKey].(string)
+
+	t, ok := get(lang, key, template)
+	if !ok && HighlightMissing {
+		return got.HTML(fmt.Sprintf("<span class=\"translation-missing\" title=\"%s\">%s</span>",
+			got.HTMLEscapeString(lang+": "+key), got.HTMLEscapeString(t)))
+	}
+
+	return got.HTML(got.HTMLEscapeString(t))
+}
+
+// Missing returns the missing translations recorded, sorted by language and key
+func Missing() []MissingTranslation {
+	missingMu.Lock()
+	defer missingMu.Unlock()
+
+	var list []MissingTranslation
+	for _, m := range missing {
+		c := *m
+		c.Templates = append([]string(nil), m.Templates...)
+		list = append(list, c)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Lang != list[j].Lang {
+			return list[i].Lang < list[j].Lang
+		}
+		return list[i].Key < list[j].Key
+	})
+
+	return list
+}
+
+// WriteMissing writes the missing translations recorded to w as json
+func WriteMissing(w io.Writer) error {
+	list := Missing()
+	if list == nil {
+		list = []MissingTranslation{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(list)
+}
+
+// MissingHandler serves the missing translations recorded as json,
+// it should only be mounted in development
+func MissingHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	err := WriteMissing(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ResetMissing clears the missing translations recorded
+func ResetMissing() {
+	missingMu.Lock()
+	defer missingMu.Unlock()
+	missing = make(map[string]*MissingTranslation)
+}
+
+// recordMissing records a missing translation for lang and key, requested by template (if known)
+func recordMissing(lang, key, template string) {
+	missingMu.Lock()
+	defer missingMu.Unlock()
+
+	m := missing[lang+key]
+	if m == nil {
+		m = &MissingTranslation{Lang: lang, Key: key}
+		missing[lang+key] = m
+	}
+	m.Count++
+
+	if template == "" {
+		return
+	}
+	for _, t := range m.Templates {
+		if t == template {
+			return
+		}
+	}
+	m.Templates = append(m.Templates, template)
+}
@@ -0,0 +1,248 @@
+package translation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pluralRule returns the index of the plural form to use for n
+type pluralRule func(n int) int
+
+// defaultPluralRule is used for languages without plural forms metadata (as in english)
+func defaultPluralRule(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// parsePluralForms parses a gettext plural forms expression
+// of the form nplurals=2; plural=(n != 1); and returns a rule to evaluate it
+func parsePluralForms(forms string) (pluralRule, error) {
+	expression := ""
+	for _, part := range strings.Split(forms, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "plural=") {
+			expression = strings.TrimPrefix(part, "plural=")
+		}
+	}
+	if expression == "" {
+		return nil, fmt.Errorf("no plural expression in plural forms %s", forms)
+	}
+
+	p := &pluralParser{tokens: tokenizePlural(expression)}
+	node, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s in plural expression %s", p.tokens[p.pos], expression)
+	}
+
+	return func(n int) int { return node(n) }, nil
+}
+
+// pluralNode evaluates part of a plural expression for n
+type pluralNode func(n int) int
+
+// pluralParser is a recursive descent parser for the c subset used in plural expressions
+type pluralParser struct {
+	tokens []string
+	pos    int
+}
+
+// tokenizePlural splits a plural expression into tokens
+func tokenizePlural(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case i+1 < len(s) && isPluralOperator(s[i:i+2]):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// isPluralOperator returns true if s is a two character operator
+func isPluralOperator(s string) bool {
+	switch s {
+	case "==", "!=", "<=", ">=", "&&", "||":
+		return true
+	}
+	return false
+}
+
+// peek returns the current token, or an empty string at the end
+func (p *pluralParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// parseTernary parses cond ? a : b
+func (p *pluralParser) parseTernary() (pluralNode, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil || p.peek() != "?" {
+		return cond, err
+	}
+	p.pos++
+	a, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ":" {
+		return nil, fmt.Errorf("expected : in plural expression")
+	}
+	p.pos++
+	b, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) int {
+		if cond(n) != 0 {
+			return a(n)
+		}
+		return b(n)
+	}, nil
+}
+
+// pluralPrecedence lists binary operators from lowest to highest precedence
+var pluralPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses binary operators at precedence level and above
+func (p *pluralParser) parseBinary(level int) (pluralNode, error) {
+	if level == len(pluralPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		found := false
+		for _, o := range pluralPrecedence[level] {
+			if op == o {
+				found = true
+			}
+		}
+		if !found {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = pluralOperator(op, left, right)
+	}
+}
+
+// parseUnary parses !x, (x), n and numbers
+func (p *pluralParser) parseUnary() (pluralNode, error) {
+	t := p.peek()
+	p.pos++
+
+	switch {
+	case t == "!":
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int { return boolInt(x(n) == 0) }, nil
+	case t == "(":
+		x, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("expected ) in plural expression")
+		}
+		p.pos++
+		return x, nil
+	case t == "n":
+		return func(n int) int { return n }, nil
+	}
+
+	v, err := strconv.Atoi(t)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %q in plural expression", t)
+	}
+	return func(n int) int { return v }, nil
+}
+
+// pluralOperator returns a node applying the binary operator op to a and b
+func pluralOperator(op string, a, b pluralNode) pluralNode {
+	switch op {
+	case "||":
+		return func(n int) int { return boolInt(a(n) != 0 || b(n) != 0) }
+	case "&&":
+		return func(n int) int { return boolInt(a(n) != 0 && b(n) != 0) }
+	case "==":
+		return func(n int) int { return boolInt(a(n) == b(n)) }
+	case "!=":
+		return func(n int) int { return boolInt(a(n) != b(n)) }
+	case "<":
+		return func(n int) int { return boolInt(a(n) < b(n)) }
+	case "<=":
+		return func(n int) int { return boolInt(a(n) <= b(n)) }
+	case ">":
+		return func(n int) int { return boolInt(a(n) > b(n)) }
+	case ">=":
+		return func(n int) int { return boolInt(a(n) >= b(n)) }
+	case "+":
+		return func(n int) int { return a(n) + b(n) }
+	case "-":
+		return func(n int) int { return a(n) - b(n) }
+	case "*":
+		return func(n int) int { return a(n) * b(n) }
+	case "/":
+		return func(n int) int {
+			if b(n) == 0 {
+				return 0
+			}
+			return a(n) / b(n)
+		}
+	default: // %
+		return func(n int) int {
+			if b(n) == 0 {
+				return 0
+			}
+			return a(n) % b(n)
+		}
+	}
+}
+
+// boolInt returns 1 for true and 0 for false, as in c
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,250 @@
+package translation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// contextSeparator separates msgctxt from msgid in keys, as in gettext mo files
+const contextSeparator = "\x04"
+
+// POLoader loads gettext .po files, including msgctxt and plural forms.
+// The language and plural forms are read from the header entry if present.
+// Entries marked fuzzy are skipped, as they are by msgfmt.
+type POLoader struct{}
+
+// CanLoadFile returns true if this loader handles this file
+func (l *POLoader) CanLoadFile(p string) bool {
+	return strings.HasSuffix(p, ".po")
+}
+
+// poEntry is a single entry in a po file
+type poEntry struct {
+	context  string
+	id       string
+	idPlural string
+	strs     map[int]string
+	fuzzy    bool
+}
+
+// Load returns the translations in the po file contents
+func (l *POLoader) Load(b []byte) (*File, error) {
+	f := &File{Translations: make(map[string]string)}
+
+	var entry *poEntry
+	var current func(string) // appends continuation lines to the last string
+	fuzzy := false
+
+	// finish adds the current entry (if any) to the file
+	finish := func() {
+		if entry != nil && !entry.fuzzy {
+			f.add(entry.context, entry.id, entry.idPlural, entry.strs)
+		}
+		entry = nil
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "":
+			finish()
+			fuzzy = false
+			continue
+		case strings.HasPrefix(text, "#,"):
+			if strings.Contains(text, "fuzzy") {
+				fuzzy = true
+			}
+			continue
+		case strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, `"`):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", line)
+			}
+			s, err := strconv.Unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			current(s)
+			continue
+		}
+
+		// Split keyword and quoted value
+		parts := strings.SplitN(text, " ", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: invalid line %s", line, text)
+		}
+		keyword := parts[0]
+		value, err := strconv.Unquote(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		// A new msgctxt or msgid after a msgstr starts a new entry
+		if (keyword == "msgctxt" || keyword == "msgid") && entry != nil && len(entry.strs) > 0 {
+			finish()
+		}
+		if entry == nil {
+			entry = &poEntry{strs: make(map[int]string), fuzzy: fuzzy}
+			fuzzy = false
+		}
+
+		e := entry
+		switch {
+		case keyword == "msgctxt":
+			e.context = value
+			current = func(s string) { e.context += s }
+		case keyword == "msgid":
+			e.id = value
+			current = func(s string) { e.id += s }
+		case keyword == "msgid_plural":
+			e.idPlural = value
+			current = func(s string) { e.idPlural += s }
+		case keyword == "msgstr":
+			e.strs[0] = value
+			current = func(s string) { e.strs[0] += s }
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			i, err := strconv.Atoi(keyword[7 : len(keyword)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid plural index %s", line, keyword)
+			}
+			e.strs[i] = value
+			current = func(s string) { e.strs[i] += s }
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %s", line, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+
+	return f, nil
+}
+
+// MOLoader loads compiled gettext .mo files, including msgctxt and plural forms.
+// The language and plural forms are read from the header entry if present.
+type MOLoader struct{}
+
+// CanLoadFile returns true if this loader handles this file
+func (l *MOLoader) CanLoadFile(p string) bool {
+	return strings.HasSuffix(p, ".mo")
+}
+
+// Load returns the translations in the mo file contents
+func (l *MOLoader) Load(b []byte) (*File, error) {
+	if len(b) < 20 {
+		return nil, fmt.Errorf("invalid mo file")
+	}
+
+	// The magic number determines byte order
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(b) == 0x950412de:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(b) == 0x950412de:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid mo file magic number")
+	}
+
+	count := int(order.Uint32(b[8:]))
+	originals := int(order.Uint32(b[12:]))
+	translations := int(order.Uint32(b[16:]))
+
+	// str returns string i from the table at offset
+	str := func(table, i int) (string, error) {
+		p := table + i*8
+		if p < 0 || p+8 > len(b) {
+			return "", fmt.Errorf("invalid mo file table offset")
+		}
+		length := int(order.Uint32(b[p:]))
+		offset := int(order.Uint32(b[p+4:]))
+		if offset < 0 || length < 0 || offset+length > len(b) {
+			return "", fmt.Errorf("invalid mo file string offset")
+		}
+		return string(b[offset : offset+length]), nil
+	}
+
+	f := &File{Translations: make(map[string]string)}
+	for i := 0; i < count; i++ {
+		original, err := str(originals, i)
+		if err != nil {
+			return nil, err
+		}
+		translation, err := str(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		// Originals are of the form [msgctxt\x04]msgid[\x00msgid_plural]
+		context := ""
+		if parts := strings.SplitN(original, contextSeparator, 2); len(parts) == 2 {
+			context, original = parts[0], parts[1]
+		}
+		ids := strings.SplitN(original, "\x00", 2)
+		idPlural := ""
+		if len(ids) == 2 {
+			idPlural = ids[1]
+		}
+
+		// Translations of plurals are separated by \x00
+		strs := make(map[int]string)
+		for j, s := range strings.Split(translation, "\x00") {
+			strs[j] = s
+		}
+
+		f.add(context, ids[0], idPlural, strs)
+	}
+
+	return f, nil
+}
+
+// add adds an entry from a po or mo file, reading metadata from the header entry.
+// Plural translations are stored with indexed keys key[0], key[1],
+// and entries with a context are stored with keys of the form context\x04key.
+func (f *File) add(context, id, idPlural string, strs map[int]string) {
+	// The header entry has an empty id and contains metadata
+	if id == "" && context == "" {
+		for _, line := range strings.Split(strs[0], "\n") {
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			switch strings.TrimSpace(parts[0]) {
+			case "Language":
+				f.Lang = strings.TrimSpace(parts[1])
+			case "Plural-Forms":
+				f.PluralForms = strings.TrimSpace(parts[1])
+			}
+		}
+		return
+	}
+
+	key := id
+	if context != "" {
+		key = context + contextSeparator + id
+	}
+
+	if idPlural == "" {
+		if strs[0] != "" {
+			f.Translations[key] = strs[0]
+		}
+		return
+	}
+
+	for i, s := range strs {
+		if s != "" {
+			f.Translations[fmt.Sprintf("%s[%d]", key, i)] = s
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package translation
+
+import (
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// TOMLLoader loads .lang.toml files, tables are flattened to dot separated keys
+type TOMLLoader struct{}
+
+// CanLoadFile returns true if this loader handles this file
+func (l *TOMLLoader) CanLoadFile(p string) bool {
+	return strings.HasSuffix(p, ".lang.toml")
+}
+
+// Load returns the translations in the toml file contents
+func (l *TOMLLoader) Load(b []byte) (*File, error) {
+	tree, err := toml.LoadBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return newNestedFile(tree.ToMap())
+}
@@ -2,21 +2,43 @@
 package translation
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io/fs"
 	"os"
-	"path"
-	"path/filepath"
-	"strings"
+	"reflect"
+	"sort"
 	"sync"
 )
 
 // DefaultLanguage defines a default language to fall back to
 var DefaultLanguage = "en"
 
-// data holds the translated data in memory (at present not split by language)
-var data map[string]string
+// catalog holds a complete set of translations loaded from files
+type catalog struct {
+	// data holds the translated data in memory (at present not split by language)
+	data map[string]string
+
+	// languages records the set of languages loaded from files
+	languages map[string]bool
+
+	// plurals holds the plural rules for languages with plural forms metadata
+	plurals map[string]pluralRule
+}
+
+// newCatalog returns a new empty catalog
+func newCatalog() *catalog {
+	return &catalog{
+		data:      make(map[string]string),
+		languages: make(map[string]bool),
+		plurals:   make(map[string]pluralRule),
+	}
+}
+
+// current is the catalog used for translations, it is replaced as a whole on Reload
+var current = newCatalog()
+
+// sources records the file systems loaded, so that they can be reloaded
+var sources []fs.FS
 
 // mu guards the translations during load and access
 var mu sync.RWMutex
@@ -27,14 +49,22 @@ var setupComplete bool
 func Setup() error {
 	mu.Lock()
 	defer mu.Unlock()
-	data = make(map[string]string)
+	current = newCatalog()
+	sources = nil
 	setupComplete = true
 	return nil
 }
 
-// Load scans the path for translation lang.json files
+// Load scans the path for translation files which one of Loaders can read
 // if called twice the second translations will overwrite any common keys
 func Load(root string) error {
+	return LoadFS(os.DirFS(root))
+}
+
+// LoadFS scans the file system given for translation files which one of Loaders can read,
+// e.g. locale files embedded with embed.FS.
+// If called twice the second translations will overwrite any common keys
+func LoadFS(fsys fs.FS) error {
 	// Ensure setup was called
 	if !setupComplete {
 		err := Setup()
@@ -47,77 +77,188 @@ func Load(root string) error {
 	mu.Lock()
 	defer mu.Unlock()
 
-	// Scan the files in the given dir, looking for our suffix
-	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+	err := current.load(fsys)
+	if err != nil {
+		return err
+	}
+
+	addSource(fsys)
+	return nil
+}
+
+// Reload reloads translations from all file systems loaded so far,
+// and replaces the whole catalog atomically, so that translations removed from files are removed.
+// If an error occurs the current translations are kept.
+func Reload() error {
+	// Lock for the whole reload, so that a concurrent LoadFS is not lost when the catalog is replaced
+	mu.Lock()
+	defer mu.Unlock()
+
+	c := newCatalog()
+	for _, fsys := range sources {
+		err := c.load(fsys)
+		if err != nil {
+			return err
+		}
+	}
+
+	current = c
+	return nil
+}
+
+// addSource records the file system for reload, if not already recorded
+func addSource(fsys fs.FS) {
+	for _, s := range sources {
+		if reflect.TypeOf(s) == reflect.TypeOf(fsys) && reflect.TypeOf(s).Comparable() && s == fsys {
+			return
+		}
+	}
+	sources = append(sources, fsys)
+}
 
+// load scans the file system given and adds the translation files found to the catalog
+func (c *catalog) load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		// Deal with files, directories we return nil error to recurse on them
-		if canParseFile(p) {
-			err = parseFile(p)
-			if err != nil {
-				return err
-			}
+		l := loaderFor(p)
+		if l != nil && !d.IsDir() {
+			return c.parseFile(fsys, l, p)
 		}
 
 		return nil
 	})
-
-	return err
 }
 
 // Get returns the translation for a given language and key
 // if no result, it falls back to DefaultLanguage + key
 func Get(lang, key string) string {
+	t, _ := get(lang, key, "")
+	return t
+}
+
+// GetContext returns the translation for a given language, context and key,
+// as set with msgctxt in po files
+func GetContext(lang, context, key string) string {
+	k := context + contextSeparator + key
+	t := Get(lang, k)
+	if t == k {
+		return key
+	}
+	return t
+}
+
+// GetPlural returns the plural form of the translation for a given language and key for count n,
+// using the plural forms of the language if set in metadata or english rules if not.
+// Plural forms are stored with indexed keys key[0], key[1] etc.
+func GetPlural(lang, key string, n int) string {
+	t, ok := get(lang, pluralKey(lang, key, n), "")
+	if ok {
+		return t
+	}
+
+	// Fall back to the default language plural rules
+	t = Get(DefaultLanguage, pluralKey(DefaultLanguage, key, n))
+	if t == pluralKey(DefaultLanguage, key, n) {
+		return key
+	}
+	return t
+}
+
+// pluralKey returns the indexed key for the plural form of key for count n in lang
+func pluralKey(lang, key string, n int) string {
+	mu.RLock()
+	rule := current.plurals[lang]
+	mu.RUnlock()
+	if rule == nil {
+		rule = defaultPluralRule
+	}
+	return fmt.Sprintf("%s[%d]", key, rule(n))
+}
+
+// get returns the translation for a given language and key, and whether it was found
+// for that language, recording misses requested by template if RecordMissing is set
+func get(lang, key, template string) (string, bool) {
 	mu.RLock()
 	defer mu.RUnlock()
 
 	// First try the language specified
-	t := data[lang+key]
+	t := current.data[lang+key]
 	if t != "" {
-		return t
+		return t, true
+	}
+
+	if RecordMissing {
+		recordMissing(lang, key, template)
 	}
 
 	// Fall back to default language if no result (in our case english)
-	t = data[DefaultLanguage+key]
+	t = current.data[DefaultLanguage+key]
 	if t != "" {
-		return t
+		return t, false
 	}
 
 	// If still no result, return key
-	return key
+	return key, false
 }
 
-// canParseFile returns true if we can parse this file
-func canParseFile(p string) bool {
-	return !strings.HasPrefix(p, ".") && strings.HasSuffix(p, ".lang.json")
+// Languages returns a sorted list of the languages loaded
+func Languages() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var langs []string
+	for l := range current.languages {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
 }
 
-// parseFile opens the file and fills in our translations,
+// Supported returns true if translations have been loaded for this language
+func Supported(lang string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current.languages[lang]
+}
+
+// parseFile opens the file and fills in our translations using the loader given,
 // returning error if a problem is encountered.
-func parseFile(p string) error {
+func (c *catalog) parseFile(fsys fs.FS, l Loader, p string) error {
 
-	// For each file, load all strings in the json file,
+	// For each file, load all strings in the file,
 	//  and add them to our list of translations
-	file, err := ioutil.ReadFile(p)
+	file, err := fs.ReadFile(fsys, p)
 	if err != nil {
 		return fmt.Errorf("Error opening file %s %v", p, err)
 	}
 
-	var langData map[string]string
-	err = json.Unmarshal(file, &langData)
+	f, err := l.Load(file)
 	if err != nil {
 		return fmt.Errorf("Error reading language file %s %v", p, err)
 	}
 
-	lang := path.Base(p)
-	lang = strings.Replace(lang, ".lang.json", "", -1)
+	// Metadata in the file takes precedence over the file name
+	lang := f.Lang
+	if lang == "" {
+		lang = langFromPath(p)
+	}
+
+	if f.PluralForms != "" {
+		rule, err := parsePluralForms(f.PluralForms)
+		if err != nil {
+			return fmt.Errorf("Error reading plural forms in language file %s %v", p, err)
+		}
+		c.plurals[lang] = rule
+	}
 
-	for k, v := range langData {
-		data[lang+k] = v
+	for k, v := range f.Translations {
+		c.data[lang+k] = v
 	}
+	c.languages[lang] = true
 
 	return nil
 }
@@ -2,10 +2,13 @@ package translation
 
 import (
 	"testing"
+
+	"github.com/fragmenta/view"
 )
 
 // TestLoad loads our files from this dir (assumes GOPATH set)
 func TestLoad(t *testing.T) {
+	isolate(t)
 	p := "test_data"
 	err := Load(p)
 	if err != nil {
@@ -16,6 +19,7 @@ func TestLoad(t *testing.T) {
 
 // TestTranslate tests translations in english and french
 func TestTranslate(t *testing.T) {
+	loadTestData(t)
 	en := Get("en", "foo")
 	fr := Get("fr", "foo")
 
@@ -27,3 +31,59 @@ func TestTranslate(t *testing.T) {
 		t.Fatalf("French translation failed:%s expected:%s", fr, "barré")
 	}
 }
+
+// TestMissing tests recording of missing translations
+func TestMissing(t *testing.T) {
+	loadTestData(t)
+	RecordMissing = true
+	HighlightMissing = true
+	defer func() {
+		RecordMissing = false
+		HighlightMissing = false
+		ResetMissing()
+	}()
+
+	Get("fr", "missing")
+	context := map[string]interface{}{view.LanguageKey: "fr", view.TemplateKey: "pages/views/show.html.got"}
+	h := string(Translate(context, "missing"))
+	if h != `<span class="translation-missing" title="fr: missing">missing</span>` {
+		t.Fatalf("Highlight missing failed:%s", h)
+	}
+
+	m := Missing()
+	if len(m) != 1 || m[0].Count != 2 || len(m[0].Templates) != 1 || m[0].Templates[0] != "pages/views/show.html.got" {
+		t.Fatalf("Record missing failed:%v", m)
+	}
+
+	if string(Translate(context, "foo")) != "barré" {
+		t.Fatalf("Translate failed for existing key")
+	}
+
+	if view.DefaultHelpers()["translate"] == nil {
+		t.Fatalf("Translate helper not registered")
+	}
+}
+
+// isolate replaces the translations with an empty catalog for the duration of the test,
+// and restores the translations loaded before when it finishes
+func isolate(t *testing.T) {
+	mu.Lock()
+	c, s, setup := current, sources, setupComplete
+	current, sources, setupComplete = newCatalog(), nil, true
+	mu.Unlock()
+
+	t.Cleanup(func() {
+		mu.Lock()
+		current, sources, setupComplete = c, s, setup
+		mu.Unlock()
+	})
+}
+
+// loadTestData loads the translations in test_data into an isolated catalog
+func loadTestData(t *testing.T) {
+	isolate(t)
+	err := Load("test_data")
+	if err != nil {
+		t.Fatalf("Load translations failed:%s", err)
+	}
+}
@@ -0,0 +1,63 @@
+package translation
+
+import (
+	"fmt"
+	got "html/template"
+	"strings"
+
+	"github.com/fragmenta/view"
+)
+
+// PrefixDefaultLanguage determines whether URLs for DefaultLanguage include a prefix,
+// by default they are left unprefixed so that /pricing is the DefaultLanguage page
+var PrefixDefaultLanguage = false
+
+// URL returns the path p localised for lang, e.g. /fr/pricing for fr and /pricing
+// any existing language prefix on p is replaced, a trailing slash on p is kept
+func URL(lang, p string) string {
+	_, p = splitPrefix(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if lang == "" || (lang == DefaultLanguage && !PrefixDefaultLanguage) {
+		return p
+	}
+	if p == "/" {
+		return "/" + lang
+	}
+	return "/" + lang + p
+}
+
+// LocalURL returns the path of the page being rendered localised for lang,
+// for use in templates as a language switcher e.g. {{localurl . "fr"}}
+func LocalURL(context map[string]interface{}, lang string) string {
+	p, _ := context[view.PathKey].(string)
+	return URL(lang, p)
+}
+
+// PageAlternateLinks returns alternate links for the page being rendered on the host at base,
+// for use in templates in the head e.g. {{alternatelinks . "https://example.com"}}
+func PageAlternateLinks(context map[string]interface{}, base string) got.HTML {
+	p, _ := context[view.PathKey].(string)
+	return AlternateLinks(base, p)
+}
+
+// AlternateLinks returns link tags with hreflang for each language loaded,
+// pointing at the localised versions of path p on the host at base (e.g. https://example.com).
+// An x-default link pointing at the DefaultLanguage version is also included.
+func AlternateLinks(base, p string) got.HTML {
+	base = strings.TrimSuffix(base, "/")
+
+	output := ""
+	for _, lang := range Languages() {
+		output += alternateLink(lang, base+URL(lang, p))
+	}
+	output += alternateLink("x-default", base+URL(DefaultLanguage, p))
+
+	return got.HTML(output)
+}
+
+// alternateLink returns a single alternate link tag
+func alternateLink(lang, href string) string {
+	return fmt.Sprintf("<link rel=\"alternate\" hreflang=\"%s\" href=\"%s\">\n", got.HTMLEscapeString(lang), got.HTMLEscapeString(href))
+}
@@ -0,0 +1,62 @@
+package translation
+
+import (
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// Watch polls the file systems loaded for changes to translation files every interval,
+// and calls Reload when a file is added, removed or modified, so that translators
+// see changes without restarting. Errors from Reload are passed to report if it is not nil.
+// It should be used in development only, and returns a function which stops watching.
+func Watch(interval time.Duration, report func(error)) (stop func()) {
+	done := make(chan struct{})
+	last := fingerprint()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				f := fingerprint()
+				if f == last {
+					continue
+				}
+				last = f
+				err := Reload()
+				if err != nil && report != nil {
+					report(err)
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// fingerprint returns a string which changes when translation files in the sources loaded change
+func fingerprint() string {
+	mu.RLock()
+	fsyss := append([]fs.FS(nil), sources...)
+	mu.RUnlock()
+
+	f := ""
+	for i, fsys := range fsyss {
+		fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || loaderFor(p) == nil {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			f += fmt.Sprintf("%d:%s:%d:%d\n", i, p, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+	}
+	return f
+}
@@ -0,0 +1,92 @@
+package translation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// TestLoadFS tests loading translations from an fs.FS
+func TestLoadFS(t *testing.T) {
+	isolate(t)
+
+	fsys := fstest.MapFS{
+		"locales/it.lang.json": {Data: []byte(`{"foo":"sbarra"}`)},
+	}
+
+	err := Setup()
+	if err != nil {
+		t.Fatalf("Setup failed:%s", err)
+	}
+	err = LoadFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFS failed:%s", err)
+	}
+	if Get("it", "foo") != "sbarra" || !Supported("it") || Supported("fr") {
+		t.Fatalf("LoadFS failed to load translations")
+	}
+}
+
+// TestWatch tests reloading translations when files change
+func TestWatch(t *testing.T) {
+	isolate(t)
+
+	dir, err := ioutil.TempDir("", "translation")
+	if err != nil {
+		t.Fatalf("Temp dir failed:%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "it.lang.json")
+	err = ioutil.WriteFile(p, []byte(`{"foo":"sbarra","old":"vecchio"}`), 0644)
+	if err != nil {
+		t.Fatalf("Write failed:%s", err)
+	}
+
+	err = Setup()
+	if err != nil {
+		t.Fatalf("Setup failed:%s", err)
+	}
+	err = Load(dir)
+	if err != nil || Get("it", "old") != "vecchio" {
+		t.Fatalf("Load failed:%s", err)
+	}
+
+	errs := make(chan error, 10)
+	stop := Watch(5*time.Millisecond, func(err error) { errs <- err })
+	defer stop()
+
+	// Keys removed from the file should be removed on reload
+	err = ioutil.WriteFile(p, []byte(`{"foo":"barra"}`), 0644)
+	if err != nil {
+		t.Fatalf("Write failed:%s", err)
+	}
+	os.Chtimes(p, time.Now(), time.Now().Add(time.Second))
+
+	for i := 0; i < 100 && Get("it", "foo") != "barra"; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if Get("it", "foo") != "barra" || Get("it", "old") != "old" {
+		t.Fatalf("Watch failed to reload translations got:%s %s", Get("it", "foo"), Get("it", "old"))
+	}
+
+	// Errors should be reported, and the current translations kept
+	err = ioutil.WriteFile(p, []byte(`{"foo":`), 0644)
+	if err != nil {
+		t.Fatalf("Write failed:%s", err)
+	}
+	os.Chtimes(p, time.Now(), time.Now().Add(2*time.Second))
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatalf("Watch failed to report reload error")
+	}
+	if Get("it", "foo") != "barra" {
+		t.Fatalf("Watch failed to keep translations after error got:%s", Get("it", "foo"))
+	}
+}
@@ -0,0 +1,28 @@
+package translation
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// YAMLLoader loads .lang.yml and .lang.yaml files, nested keys are flattened to dot separated keys
+type YAMLLoader struct{}
+
+// CanLoadFile returns true if this loader handles this file
+func (l *YAMLLoader) CanLoadFile(p string) bool {
+	return strings.HasSuffix(p, ".lang.yml") || strings.HasSuffix(p, ".lang.yaml")
+}
+
+// Load returns the translations in the yaml file contents
+func (l *YAMLLoader) Load(b []byte) (*File, error) {
+	var values map[string]interface{}
+	err := yaml.Unmarshal(b, &values)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	return newNestedFile(values)
+}
@@ -2,7 +2,9 @@
 package view
 
 import (
+	"bytes"
 	"fmt"
+	got "html/template"
 	"sync"
 
 	"github.com/fragmenta/view/helpers"
@@ -22,10 +24,30 @@ var mu sync.RWMutex
 // Helpers is a list of functions available in templates
 var Helpers parser.FuncMap
 
+// registeredHelpers holds the helpers registered by other packages, which are included in DefaultHelpers
+var registeredHelpers = make(parser.FuncMap)
+
+// RegisterHelpers adds helpers from packages which cannot be imported by view (e.g. translation)
+// to Helpers and DefaultHelpers. It should be called in init, before templates are loaded.
+func RegisterHelpers(funcs parser.FuncMap) {
+	for k, f := range funcs {
+		registeredHelpers[k] = f
+		Helpers[k] = f
+	}
+}
+
 func init() {
 	Helpers = DefaultHelpers()
+	helpers.PartialRenderer = renderPartial
+	parser.MarkdownRenderer = helpers.RenderMarkdown
 }
 
+// ContextHelpers lists the default helpers which are passed the render context automatically
+// by the template parser, so that dates are shown in the time zone of the request,
+// and {{csrf}} renders the authenticity token of the request. It is read when templates are loaded,
+// and helpers which replace these are only passed the context if they accept ...interface{}
+var ContextHelpers = []string{"date", "time", "ago", "timetag", "langdate", "langtime", "langago", "csrf", "csrfmeta"}
+
 // LoadTemplates loads our templates from ./src, and assigns them to the package variable Templates
 // This function is deprecated and will be removed, use LoadTemplatesAtPaths instead
 func LoadTemplates() error {
@@ -46,11 +68,15 @@ func DefaultHelpers() parser.FuncMap {
 	// HTML helpers
 	funcs["html"] = helpers.HTML
 	funcs["htmlattr"] = helpers.HTMLAttribute
+	funcs["attrs"] = helpers.NewAttrs
+	funcs["link"] = helpers.Link
 	funcs["url"] = helpers.URL
 
 	funcs["sanitize"] = helpers.Sanitize
 	funcs["strip"] = helpers.Strip
+	funcs["markdown"] = helpers.Markdown
 	funcs["truncate"] = helpers.Truncate
+	funcs["truncatehtml"] = helpers.TruncateHTML
 
 	// XML helpers
 	funcs["xmlpreamble"] = helpers.XMLPreamble
@@ -63,43 +89,107 @@ func DefaultHelpers() parser.FuncMap {
 	funcs["csv"] = helpers.CSV
 
 	// Form helpers
+	funcs["csrf"] = helpers.CSRF
+	funcs["csrfmeta"] = helpers.CSRFMeta
+	funcs["form"] = helpers.Form
+	funcs["errorsummary"] = helpers.ErrorSummary
 	funcs["field"] = helpers.Field
 	funcs["datefield"] = helpers.DateField
 	funcs["textarea"] = helpers.TextArea
+	funcs["checkbox"] = helpers.Checkbox
+	funcs["radiogroup"] = helpers.RadioGroup
+	funcs["selectmultiple"] = helpers.SelectMultiple
+	funcs["numberfield"] = helpers.NumberField
+	funcs["rangefield"] = helpers.RangeField
+	funcs["emailfield"] = helpers.EmailField
+	funcs["telfield"] = helpers.TelField
+	funcs["urlfield"] = helpers.URLField
+	funcs["datetimefield"] = helpers.DateTimeField
+	funcs["timefield"] = helpers.TimeField
+	funcs["colorfield"] = helpers.ColorField
+	funcs["hiddenfield"] = helpers.HiddenField
+	funcs["filefield"] = helpers.FileField
 	funcs["select"] = helpers.Select
 	funcs["selectarray"] = helpers.SelectArray
 	funcs["optionsforselect"] = helpers.OptionsForSelect
+	funcs["selectoptions"] = helpers.SelectOptions
+	funcs["paginate"] = helpers.Paginate
 
 	funcs["utcdate"] = helpers.UTCDate
 	funcs["utctime"] = helpers.UTCTime
 	funcs["utcnow"] = helpers.UTCNow
 	funcs["year"] = helpers.YearNow
-	funcs["date"] = helpers.Date
-	funcs["time"] = helpers.Time
+	funcs["date"] = helpers.ZoneDate
+	funcs["time"] = helpers.ZoneTime
 	funcs["ago"] = helpers.Ago
+	funcs["inzone"] = helpers.InZone
+	funcs["localtime"] = helpers.LocalTime
+	funcs["timetag"] = helpers.TimeTag
 	funcs["numberoptions"] = helpers.NumberOptions
 
+	// Localised date and number helpers, which take a lang e.g. {{langdate .lang .date}}
+	funcs["langdate"] = helpers.LangDate
+	funcs["langtime"] = helpers.LangTime
+	funcs["langago"] = helpers.LangAgo
+	funcs["langnumber"] = helpers.LangNumber
+	funcs["langnumbertocommas"] = helpers.LangNumberToCommas
+	funcs["langnumbertohuman"] = helpers.LangNumberToHuman
+
 	// String helpers
 	funcs["blank"] = helpers.Blank
 	funcs["exists"] = helpers.Exists
+	funcs["titlecase"] = helpers.TitleCase
+	funcs["upper"] = helpers.Upper
+	funcs["lower"] = helpers.Lower
+	funcs["slugify"] = helpers.Slugify
+	funcs["pluralize"] = helpers.Pluralize
+	funcs["singularize"] = helpers.Singularize
+	funcs["wordwrap"] = helpers.WordWrap
+	funcs["nl2br"] = helpers.Nl2br
+	funcs["excerpt"] = helpers.Excerpt
+	funcs["highlight"] = helpers.Highlight
 
-	// Math helpers
-	funcs["mod"] = helpers.Mod
-	funcs["odd"] = helpers.Odd
+	// Math helpers, which accept any int, uint or float type
 	funcs["add"] = helpers.Add
+	funcs["sub"] = helpers.Subtract
 	funcs["subtract"] = helpers.Subtract
+	funcs["mul"] = helpers.Multiply
+	funcs["div"] = helpers.Divide
+	funcs["mod"] = helpers.Mod
+	funcs["min"] = helpers.Min
+	funcs["max"] = helpers.Max
+	funcs["round"] = helpers.Round
+	funcs["ceil"] = helpers.Ceil
+	funcs["floor"] = helpers.Floor
+	funcs["abs"] = helpers.Abs
+	funcs["even"] = helpers.Even
+	funcs["odd"] = helpers.Odd
 	funcs["int64"] = helpers.Int64
 
-	// Array functions
+	// Array functions, sublist and length do not replace the slice and len builtins
 	funcs["array"] = helpers.Array
 	funcs["append"] = helpers.Append
 	funcs["contains"] = helpers.Contains
+	funcs["list"] = helpers.List
+	funcs["first"] = helpers.First
+	funcs["last"] = helpers.Last
+	funcs["sublist"] = helpers.Slice
+	funcs["reverse"] = helpers.Reverse
+	funcs["sortby"] = helpers.SortBy
+	funcs["groupby"] = helpers.GroupBy
+	funcs["pluck"] = helpers.Pluck
+	funcs["uniq"] = helpers.Uniq
+	funcs["join"] = helpers.Join
+	funcs["length"] = helpers.Len
+	funcs["chunk"] = helpers.Chunk
 
 	// Map functions
 	funcs["map"] = helpers.Map
 	funcs["set"] = helpers.Set
 	funcs["setif"] = helpers.SetIf
 	funcs["empty"] = helpers.Empty
+	funcs["dict"] = helpers.Dict
+	funcs["keys"] = helpers.Keys
 
 	// Numeric helpers - clean up and accept currency and other options in centstoprice
 	funcs["centstobase"] = helpers.CentsToBase
@@ -108,6 +198,18 @@ func DefaultHelpers() parser.FuncMap {
 	funcs["pricetocents"] = helpers.PriceToCents
 	funcs["numbertohuman"] = helpers.NumberToHuman
 	funcs["numbertocommas"] = helpers.NumberToCommas
+	funcs["compact"] = helpers.Compact
+	funcs["percent"] = helpers.Percent
+	funcs["filesize"] = helpers.FileSize
+	funcs["filesizeiec"] = helpers.FileSizeIEC
+	funcs["ordinal"] = helpers.Ordinal
+	funcs["precision"] = helpers.Precision
+	funcs["money"] = helpers.Money
+
+	// Helpers registered by other packages
+	for k, f := range registeredHelpers {
+		funcs[k] = f
+	}
 
 	return funcs
 }
@@ -119,6 +221,7 @@ func LoadTemplatesAtPaths(paths []string, helpers parser.FuncMap) error {
 	defer mu.Unlock()
 
 	// Scan all templates within the given paths, using the helpers provided
+	parser.ContextHelpers = ContextHelpers
 	var err error
 	scanner, err = parser.NewScanner(paths, helpers)
 	if err != nil {
@@ -137,6 +240,7 @@ func LoadTemplatesAtPaths(paths []string, helpers parser.FuncMap) error {
 func ReloadTemplates() error {
 	mu.Lock()
 	defer mu.Unlock()
+	parser.ContextHelpers = ContextHelpers
 	return scanner.ScanPaths()
 }
 
@@ -149,3 +253,24 @@ func PrintTemplates() {
 	}
 	fmt.Printf("Finished scan of templates\n")
 }
+
+// renderPartial renders the template at path with the context given, returning false if there is no such template,
+// it is used by helpers which render partials so that apps may override them
+func renderPartial(path string, context map[string]interface{}) (got.HTML, bool, error) {
+	mu.RLock()
+	var t parser.Template
+	if scanner != nil {
+		t = scanner.Templates[path]
+	}
+	mu.RUnlock()
+	if t == nil {
+		return "", false, nil
+	}
+
+	var rendered bytes.Buffer
+	err := t.Render(&rendered, context)
+	if err != nil {
+		return "", true, err
+	}
+	return got.HTML(rendered.String()), true, nil
+}
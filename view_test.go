@@ -5,6 +5,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/fragmenta/view/helpers"
 )
 
 func TestLoad(t *testing.T) {
@@ -60,3 +63,178 @@ func TestLoad(t *testing.T) {
 	}
 
 }
+
+func TestMarkdownTemplate(t *testing.T) {
+	err := LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	v := NewRenderer(w, r)
+	v.AddKey("title", "Pricing")
+	v.AddKey("name", "<script>alert(1)</script>")
+	v.AddKey("plans", []string{"Basic", "Pro"})
+	v.Template("page.md.got")
+	v.Layout("layout.html.got")
+
+	s, err := v.RenderToStringWithLayout()
+	if err != nil {
+		t.Fatalf("error rendering markdown template:%s", err)
+	}
+
+	expected := []string{
+		"<main><h1>Pricing</h1>",
+		`<th align="left">Plan</th>`,
+		`<td align="right">free</td>`,
+		`<td align="left">Pro</td>`,
+		`<input type="checkbox" checked="checked" disabled="disabled"> done`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(s, e) {
+			t.Errorf("markdown template missing %s got:%s", e, s)
+		}
+	}
+	if strings.Contains(s, "<script>") {
+		t.Errorf("markdown template not sanitized got:%s", s)
+	}
+}
+
+func TestFormPartials(t *testing.T) {
+	err := LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+
+	// The app partial in test_data/forms replaces the default field partial
+	s, err := helpers.Field("Name", "name", "value")
+	if err != nil || !strings.Contains(string(s), `<input class="form-control" name="name" value="value" type="text">`) {
+		t.Errorf("error rendering form partial got:%s %v", s, err)
+	}
+}
+
+func TestZoneHelpers(t *testing.T) {
+	err := LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), TimeZoneContext, "Europe/Paris"))
+	if helpers.Location("Europe/Paris") == nil {
+		t.Skipf("zone data not available")
+	}
+
+	v := NewRenderer(httptest.NewRecorder(), r)
+	v.AddKey("t", time.Date(2016, time.March, 7, 23, 30, 0, 0, time.UTC))
+	v.AddKey("utc", time.UTC)
+	v.Template("zones.html.got")
+
+	// Date helpers use the request zone unless given a zone, piped values are not converted
+	s, err := v.RenderToString()
+	if err != nil || !strings.Contains(s, "<p>00:30 23:30 00:30 Mar 7, 2016</p>") {
+		t.Errorf("error rendering zones got:%s %v", s, err)
+	}
+}
+
+// TestContextHelpers tests the context is only passed to helpers which accept it,
+// and that changes to ContextHelpers apply when templates are loaded
+func TestContextHelpers(t *testing.T) {
+	h := DefaultHelpers()
+	h["date"] = func(t time.Time, args ...string) string { return "custom" }
+	err := LoadTemplatesAtPaths([]string{"test_data"}, h)
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+	v := NewRenderer(httptest.NewRecorder(), nil)
+	v.AddKey("t", time.Date(2016, time.March, 7, 23, 30, 0, 0, time.UTC))
+	v.AddKey("utc", time.UTC)
+	v.Template("zones.html.got")
+	s, err := v.RenderToString()
+	if err != nil || !strings.Contains(s, "<p>custom 23:30 custom custom</p>") {
+		t.Errorf("error rendering replaced helper got:%s %v", s, err)
+	}
+
+	defer func(c []string) { ContextHelpers = c }(ContextHelpers)
+	ContextHelpers = []string{"time"}
+	err = LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), TimeZoneContext, "Europe/Paris"))
+	v = NewRenderer(httptest.NewRecorder(), r)
+	v.AddKey("t", time.Date(2016, time.March, 7, 23, 30, 0, 0, time.UTC))
+	v.AddKey("utc", time.UTC)
+	v.Template("zones.html.got")
+	s, err = v.RenderToString()
+	if err != nil || !strings.Contains(s, "<p>23:30 23:30 23:30 Mar 7, 2016</p>") {
+		t.Errorf("error rendering changed context helpers got:%s %v", s, err)
+	}
+}
+
+func TestCollectionHelpers(t *testing.T) {
+	err := LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+
+	// The slice and len builtins are not replaced by collection helpers
+	v := NewRenderer(httptest.NewRecorder(), nil)
+	v.AddKey("list", []string{"a", "b", "c"})
+	v.Template("collections.html.got")
+	s, err := v.RenderToString()
+	if err != nil || !strings.Contains(s, "<p>hel 3 [b c] 0 </p>") {
+		t.Errorf("error rendering collections got:%s %v", s, err)
+	}
+
+	v.AddKey("n", 5)
+	_, err = v.RenderToString()
+	if err == nil {
+		t.Errorf("error rendering collections len of int did not fail")
+	}
+}
+
+func TestRendererContext(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), AuthenticityContext, "token"))
+
+	// MergeContext merges keys with those set before, so errors and request values are kept
+	errors := helpers.FormErrors{}
+	errors.Add("name", "Name is required")
+	v := NewRenderer(httptest.NewRecorder(), r)
+	v.Errors(errors).MergeContext(map[string]interface{}{"name": "x"})
+	if v.context[errorsKey] == nil || v.context[authenticityKey] != "token" || v.context["name"] != "x" {
+		t.Errorf("error merging render context got:%v", v.context)
+	}
+
+	// Context replaces keys set before, except errors, which may be set before or after
+	v = NewRenderer(httptest.NewRecorder(), r)
+	v.Errors(errors).Context(map[string]interface{}{"name": "y"})
+	if v.context[errorsKey] == nil || v.context[authenticityKey] != nil || v.context["name"] != "y" {
+		t.Errorf("error replacing render context got:%v", v.context)
+	}
+	v = NewRenderer(httptest.NewRecorder(), r)
+	v.Context(map[string]interface{}{"name": "y"}).Errors(errors)
+	if v.context[errorsKey] == nil || len(v.context) != 2 {
+		t.Errorf("error setting errors after render context got:%v", v.context)
+	}
+}
+
+func TestCSRFHelper(t *testing.T) {
+	err := LoadTemplatesAtPaths([]string{"test_data"}, DefaultHelpers())
+	if err != nil {
+		t.Fatalf("error loading templates:%s", err)
+	}
+
+	// The render context is passed to csrf automatically
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), AuthenticityContext, "token"))
+	v := NewRenderer(httptest.NewRecorder(), r)
+	v.Template("csrf.html.got")
+	s, err := v.RenderToString()
+	if err != nil || !strings.Contains(s, `<input type="hidden" name="authenticity_token" value="token">`) {
+		t.Errorf("error rendering csrf got:%s %v", s, err)
+	}
+}